@@ -7,9 +7,15 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Embedded zone data lets digests use any IANA timezone.
+	_ "time/tzdata"
 
 	"parish-viva/backend/internal/config"
 	apphttp "parish-viva/backend/internal/http"
+	"parish-viva/backend/internal/jobs"
+	"parish-viva/backend/internal/mail"
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/push"
 	"parish-viva/backend/internal/repositories"
 	"parish-viva/backend/internal/services"
 
@@ -38,8 +44,39 @@ func main() {
 	}
 	defer dbpool.Close()
 
+	mailer, err := newMailer(cfg, logger)
+	if err != nil {
+		logger.Fatal("mailer_setup_failed", zap.Error(err))
+	}
+	pushSender, err := newPushSender(cfg)
+	if err != nil {
+		logger.Fatal("push_setup_failed", zap.Error(err))
+	}
+
 	repo := repositories.NewPostgresRepository(dbpool)
-	svc := services.NewService(repo)
+	svc := services.NewService(repo, services.Options{
+		PublicModeration:        cfg.PublicModerationEnabled,
+		ReportAutoHideThreshold: cfg.ReportAutoHideThreshold,
+		Archival: models.ArchivalPolicy{
+			InactiveFor: cfg.ArchiveInactiveAfter,
+			WarnBefore:  cfg.ArchiveWarningPeriod,
+		},
+		NotificationStreamsPerUser: cfg.NotificationStreamsPerUser,
+		Email: services.EmailOptions{
+			Mailer:      mailer,
+			AppBaseURL:  cfg.AppBaseURL,
+			MaxAttempts: cfg.EmailMaxAttempts,
+			APIBaseURL:  cfg.APIBaseURL,
+			DigestHour:  cfg.DigestSendHour,
+		},
+		Push: services.PushOptions{
+			Sender:      pushSender,
+			PublicKey:   cfg.VAPIDPublicKey,
+			AppBaseURL:  cfg.AppBaseURL,
+			MaxAttempts: cfg.PushMaxAttempts,
+		},
+		NotificationRetention: cfg.NotificationRetention,
+	})
 	router := apphttp.NewRouter(cfg, logger, svc)
 
 	srv := &http.Server{
@@ -50,6 +87,19 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go jobs.NewArchiver(svc, logger, cfg.ArchiveJobInterval).Run(jobsCtx)
+	go jobs.NewNotificationListener(svc, logger).Run(jobsCtx)
+	go jobs.NewNotificationRetention(svc, logger, cfg.NotificationRetentionJobInterval).Run(jobsCtx)
+	if mailer != nil {
+		go jobs.NewEmailOutbox(svc, logger, cfg.EmailOutboxInterval).Run(jobsCtx)
+		go jobs.NewEmailDigests(svc, logger, cfg.DigestJobInterval).Run(jobsCtx)
+	}
+	if pushSender != nil {
+		go jobs.NewPushOutbox(svc, logger, cfg.PushOutboxInterval).Run(jobsCtx)
+	}
+
 	go func() {
 		logger.Info("api_server_started", zap.String("addr", cfg.HTTPAddr))
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -60,6 +110,7 @@ func main() {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	<-sigCh
+	stopJobs()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
@@ -67,3 +118,35 @@ func main() {
 		logger.Error("api_server_shutdown_failed", zap.Error(err))
 	}
 }
+
+// newMailer returns nil, turning email off, for MAIL_DRIVER=none.
+func newMailer(cfg config.Config, logger *zap.Logger) (mail.Mailer, error) {
+	switch cfg.MailDriver {
+	case "smtp":
+		return mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+		})
+	case "file":
+		return mail.NewFileMailer(cfg.MailFileDir, cfg.MailFrom)
+	case "log":
+		return mail.NewLogMailer(logger), nil
+	default:
+		return nil, nil
+	}
+}
+
+// newPushSender returns nil, turning push off, when no VAPID keys are set.
+func newPushSender(cfg config.Config) (push.Sender, error) {
+	if cfg.VAPIDPrivateKey == "" {
+		return nil, nil
+	}
+	return push.NewClient(push.Config{
+		PublicKey:  cfg.VAPIDPublicKey,
+		PrivateKey: cfg.VAPIDPrivateKey,
+		Subject:    cfg.VAPIDSubject,
+	})
+}
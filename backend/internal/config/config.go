@@ -9,30 +9,95 @@ import (
 )
 
 type Config struct {
-	HTTPAddr             string
-	DatabaseURL          string
-	JWTIssuer            string
-	JWKSURL              string
-	JWKSCacheTTL         time.Duration
-	RateLimitRequests    int
-	RateLimitWindow      time.Duration
-	PrayedWindowHours    int
-	PrayedIPBurstPerHour int
-	CORSAllowedOrigins   []string
+	// Env is APP_ENV; production rejects settings only fit for development.
+	Env                     string
+	HTTPAddr                string
+	DatabaseURL             string
+	JWTIssuer               string
+	JWKSURL                 string
+	JWKSCacheTTL            time.Duration
+	RateLimitRequests       int
+	RateLimitWindow         time.Duration
+	PrayedWindowHours       int
+	PrayedIPBurstPerHour    int
+	CORSAllowedOrigins      []string
+	PublicModerationEnabled bool
+	ReportAutoHideThreshold int
+	ArchiveInactiveAfter    time.Duration
+	ArchiveWarningPeriod    time.Duration
+	ArchiveJobInterval      time.Duration
+
+	NotificationStreamsPerUser  int
+	NotificationStreamHeartbeat time.Duration
+
+	MailDriver          string
+	MailFrom            string
+	MailFileDir         string
+	SMTPHost            string
+	SMTPPort            int
+	SMTPUsername        string
+	SMTPPassword        string
+	AppBaseURL          string
+	EmailOutboxInterval time.Duration
+	EmailMaxAttempts    int
+	APIBaseURL          string
+	DigestSendHour      int
+	DigestJobInterval   time.Duration
+
+	VAPIDPublicKey     string
+	VAPIDPrivateKey    string
+	VAPIDSubject       string
+	PushOutboxInterval time.Duration
+	PushMaxAttempts    int
+
+	NotificationRetention            time.Duration
+	NotificationRetentionJobInterval time.Duration
 }
 
 func Load() (Config, error) {
 	cfg := Config{
-		HTTPAddr:             resolveHTTPAddr(),
-		DatabaseURL:          os.Getenv("DATABASE_URL"),
-		JWTIssuer:            os.Getenv("JWT_ISSUER"),
-		JWKSURL:              os.Getenv("JWKS_URL"),
-		JWKSCacheTTL:         durationOrDefault("JWKS_CACHE_TTL", 10*time.Minute),
-		RateLimitRequests:    intOrDefault("RATE_LIMIT_REQUESTS", 120),
-		RateLimitWindow:      durationOrDefault("RATE_LIMIT_WINDOW", time.Minute),
-		PrayedWindowHours:    intOrDefault("PRAYED_WINDOW_HOURS", 12),
-		PrayedIPBurstPerHour: intOrDefault("PRAYED_IP_BURST_PER_HOUR", 200),
-		CORSAllowedOrigins:   csvOrDefault("CORS_ALLOWED_ORIGINS", []string{"http://localhost:5173", "http://127.0.0.1:5173", "http://localhost:5174", "http://127.0.0.1:5174"}),
+		Env:                     envOrDefault("APP_ENV", "development"),
+		HTTPAddr:                resolveHTTPAddr(),
+		DatabaseURL:             os.Getenv("DATABASE_URL"),
+		JWTIssuer:               os.Getenv("JWT_ISSUER"),
+		JWKSURL:                 os.Getenv("JWKS_URL"),
+		JWKSCacheTTL:            durationOrDefault("JWKS_CACHE_TTL", 10*time.Minute),
+		RateLimitRequests:       intOrDefault("RATE_LIMIT_REQUESTS", 120),
+		RateLimitWindow:         durationOrDefault("RATE_LIMIT_WINDOW", time.Minute),
+		PrayedWindowHours:       intOrDefault("PRAYED_WINDOW_HOURS", 12),
+		PrayedIPBurstPerHour:    intOrDefault("PRAYED_IP_BURST_PER_HOUR", 200),
+		CORSAllowedOrigins:      csvOrDefault("CORS_ALLOWED_ORIGINS", []string{"http://localhost:5173", "http://127.0.0.1:5173", "http://localhost:5174", "http://127.0.0.1:5174"}),
+		PublicModerationEnabled: boolOrDefault("PUBLIC_MODERATION_ENABLED", false),
+		ReportAutoHideThreshold: intOrDefault("REPORT_AUTO_HIDE_THRESHOLD", 3),
+		ArchiveInactiveAfter:    durationOrDefault("ARCHIVE_INACTIVE_AFTER", 90*24*time.Hour),
+		ArchiveWarningPeriod:    durationOrDefault("ARCHIVE_WARNING_PERIOD", 7*24*time.Hour),
+		ArchiveJobInterval:      durationOrDefault("ARCHIVE_JOB_INTERVAL", time.Hour),
+
+		NotificationStreamsPerUser:  intOrDefault("NOTIFICATION_STREAMS_PER_USER", 3),
+		NotificationStreamHeartbeat: durationOrDefault("NOTIFICATION_STREAM_HEARTBEAT", 25*time.Second),
+
+		MailDriver:          envOrDefault("MAIL_DRIVER", "none"),
+		MailFrom:            envOrDefault("MAIL_FROM", "Creo <no-reply@localhost>"),
+		MailFileDir:         envOrDefault("MAIL_FILE_DIR", "tmp/mail"),
+		SMTPHost:            envOrDefault("SMTP_HOST", "localhost"),
+		SMTPPort:            intOrDefault("SMTP_PORT", 1025),
+		SMTPUsername:        os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:        os.Getenv("SMTP_PASSWORD"),
+		AppBaseURL:          envOrDefault("APP_BASE_URL", "http://localhost:5173"),
+		EmailOutboxInterval: durationOrDefault("EMAIL_OUTBOX_INTERVAL", 30*time.Second),
+		EmailMaxAttempts:    intOrDefault("EMAIL_MAX_ATTEMPTS", 8),
+		APIBaseURL:          envOrDefault("API_BASE_URL", "http://localhost:8080"),
+		DigestSendHour:      intOrDefault("DIGEST_SEND_HOUR", 7),
+		DigestJobInterval:   durationOrDefault("DIGEST_JOB_INTERVAL", 15*time.Minute),
+
+		VAPIDPublicKey:     os.Getenv("VAPID_PUBLIC_KEY"),
+		VAPIDPrivateKey:    os.Getenv("VAPID_PRIVATE_KEY"),
+		VAPIDSubject:       envOrDefault("VAPID_SUBJECT", "mailto:no-reply@localhost"),
+		PushOutboxInterval: durationOrDefault("PUSH_OUTBOX_INTERVAL", 10*time.Second),
+		PushMaxAttempts:    intOrDefault("PUSH_MAX_ATTEMPTS", 5),
+
+		NotificationRetention:            durationOrDefault("NOTIFICATION_RETENTION", 90*24*time.Hour),
+		NotificationRetentionJobInterval: durationOrDefault("NOTIFICATION_RETENTION_JOB_INTERVAL", time.Hour),
 	}
 	if cfg.DatabaseURL == "" {
 		return Config{}, errors.New("DATABASE_URL is required")
@@ -43,6 +108,50 @@ func Load() (Config, error) {
 	if cfg.JWKSURL == "" {
 		return Config{}, errors.New("JWKS_URL is required")
 	}
+	if cfg.ArchiveInactiveAfter > 0 && (cfg.ArchiveWarningPeriod < 0 || cfg.ArchiveWarningPeriod >= cfg.ArchiveInactiveAfter) {
+		return Config{}, errors.New("ARCHIVE_WARNING_PERIOD must be shorter than ARCHIVE_INACTIVE_AFTER")
+	}
+	if cfg.ArchiveJobInterval <= 0 {
+		return Config{}, errors.New("ARCHIVE_JOB_INTERVAL must be positive")
+	}
+	if cfg.NotificationStreamHeartbeat <= 0 {
+		return Config{}, errors.New("NOTIFICATION_STREAM_HEARTBEAT must be positive")
+	}
+	if cfg.Env == "production" && strings.TrimSpace(os.Getenv("MAIL_DRIVER")) == "" {
+		return Config{}, errors.New("MAIL_DRIVER is required when APP_ENV is production")
+	}
+	switch cfg.MailDriver {
+	case "none", "log", "file", "smtp":
+	default:
+		return Config{}, errors.New("MAIL_DRIVER must be one of none, log, file, smtp")
+	}
+	if cfg.EmailOutboxInterval <= 0 {
+		return Config{}, errors.New("EMAIL_OUTBOX_INTERVAL must be positive")
+	}
+	if cfg.EmailMaxAttempts < 1 {
+		return Config{}, errors.New("EMAIL_MAX_ATTEMPTS must be at least 1")
+	}
+	if cfg.DigestSendHour < 0 || cfg.DigestSendHour > 23 {
+		return Config{}, errors.New("DIGEST_SEND_HOUR must be between 0 and 23")
+	}
+	if cfg.DigestJobInterval <= 0 {
+		return Config{}, errors.New("DIGEST_JOB_INTERVAL must be positive")
+	}
+	if (cfg.VAPIDPublicKey == "") != (cfg.VAPIDPrivateKey == "") {
+		return Config{}, errors.New("VAPID_PUBLIC_KEY and VAPID_PRIVATE_KEY must be set together")
+	}
+	if cfg.PushOutboxInterval <= 0 {
+		return Config{}, errors.New("PUSH_OUTBOX_INTERVAL must be positive")
+	}
+	if cfg.PushMaxAttempts < 1 {
+		return Config{}, errors.New("PUSH_MAX_ATTEMPTS must be at least 1")
+	}
+	if cfg.NotificationRetention < 0 {
+		return Config{}, errors.New("NOTIFICATION_RETENTION must not be negative")
+	}
+	if cfg.NotificationRetentionJobInterval <= 0 {
+		return Config{}, errors.New("NOTIFICATION_RETENTION_JOB_INTERVAL must be positive")
+	}
 	return cfg, nil
 }
 
@@ -88,6 +197,18 @@ func durationOrDefault(key string, value time.Duration) time.Duration {
 	return d
 }
 
+func boolOrDefault(key string, value bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return value
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return value
+	}
+	return b
+}
+
 func csvOrDefault(key string, value []string) []string {
 	v := strings.TrimSpace(os.Getenv(key))
 	if v == "" {
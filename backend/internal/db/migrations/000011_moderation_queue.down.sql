@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_moderation_queue_group_status;
+DROP INDEX IF EXISTS idx_moderation_queue_open_item;
+
+ALTER TABLE moderation_queue
+    DROP COLUMN IF EXISTS claimed_at,
+    DROP COLUMN IF EXISTS claimed_by;
+
+ALTER TABLE moderation_queue DROP CONSTRAINT IF EXISTS moderation_queue_status_check;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_platform_role_check;
+ALTER TABLE users DROP COLUMN IF EXISTS platform_role;
@@ -0,0 +1,25 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS platform_role TEXT NOT NULL DEFAULT 'USER';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'users_platform_role_check') THEN
+        ALTER TABLE users
+            ADD CONSTRAINT users_platform_role_check CHECK (platform_role IN ('USER', 'MODERATOR', 'ADMIN'));
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'moderation_queue_status_check') THEN
+        ALTER TABLE moderation_queue
+            ADD CONSTRAINT moderation_queue_status_check CHECK (status IN ('PENDING', 'IN_REVIEW', 'RESOLVED'));
+    END IF;
+END $$;
+
+ALTER TABLE moderation_queue
+    ADD COLUMN IF NOT EXISTS claimed_by UUID REFERENCES users(id),
+    ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMPTZ;
+
+-- At most one open queue item per request and group (NULL group = platform scope).
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_queue_open_item
+    ON moderation_queue (prayer_request_id, COALESCE(group_id, '00000000-0000-0000-0000-000000000000'::uuid))
+    WHERE status <> 'RESOLVED';
+
+CREATE INDEX IF NOT EXISTS idx_moderation_queue_group_status
+    ON moderation_queue (group_id, status, created_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_moderation_actions_target_group_id;
+DROP INDEX IF EXISTS idx_moderation_actions_reverts;
+
+ALTER TABLE moderation_queue DROP COLUMN IF EXISTS resolved_by;
+
+-- Postgres cannot drop a single enum value; 'RESTORE' stays in moderation_action_type.
@@ -0,0 +1,11 @@
+ALTER TYPE moderation_action_type ADD VALUE IF NOT EXISTS 'RESTORE';
+
+ALTER TABLE moderation_queue ADD COLUMN IF NOT EXISTS resolved_by UUID REFERENCES users(id);
+
+-- An action can be reverted at most once.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_actions_reverts
+    ON moderation_actions ((payload->>'revertsActionId'))
+    WHERE payload ? 'revertsActionId';
+
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_target_group_id
+    ON moderation_actions (target_group_id, created_at DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bans_user_id;
+
+-- Postgres cannot drop a single enum value; 'UNBAN' stays in moderation_action_type.
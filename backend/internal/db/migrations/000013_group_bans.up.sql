@@ -0,0 +1,3 @@
+ALTER TYPE moderation_action_type ADD VALUE IF NOT EXISTS 'UNBAN';
+
+CREATE INDEX IF NOT EXISTS idx_bans_user_id ON bans (user_id);
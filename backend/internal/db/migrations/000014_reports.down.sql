@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS reports;
+
+DROP INDEX IF EXISTS idx_moderation_queue_open_user;
+
+ALTER TABLE moderation_queue DROP CONSTRAINT IF EXISTS moderation_queue_target_check;
+DELETE FROM moderation_queue WHERE prayer_request_id IS NULL;
+ALTER TABLE moderation_queue
+    DROP COLUMN IF EXISTS report_count,
+    DROP COLUMN IF EXISTS target_user_id;
+ALTER TABLE moderation_queue ALTER COLUMN prayer_request_id SET NOT NULL;
+
+-- Postgres cannot drop a single enum value; 'DISMISS' stays in moderation_action_type.
@@ -0,0 +1,45 @@
+ALTER TYPE moderation_action_type ADD VALUE IF NOT EXISTS 'DISMISS';
+
+-- Queue items can now target a user (user reports) instead of a request.
+ALTER TABLE moderation_queue ALTER COLUMN prayer_request_id DROP NOT NULL;
+ALTER TABLE moderation_queue
+    ADD COLUMN IF NOT EXISTS target_user_id UUID REFERENCES users(id),
+    ADD COLUMN IF NOT EXISTS report_count INT NOT NULL DEFAULT 0;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'moderation_queue_target_check') THEN
+        ALTER TABLE moderation_queue
+            ADD CONSTRAINT moderation_queue_target_check
+            CHECK (prayer_request_id IS NOT NULL OR target_user_id IS NOT NULL);
+    END IF;
+END $$;
+
+-- At most one open queue item per reported user.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_queue_open_user
+    ON moderation_queue (target_user_id)
+    WHERE prayer_request_id IS NULL AND status <> 'RESOLVED';
+
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reporter_id UUID NOT NULL REFERENCES users(id),
+    target_request_id UUID REFERENCES prayer_requests(id),
+    target_user_id UUID REFERENCES users(id),
+    reason TEXT NOT NULL CHECK (reason IN ('SPAM', 'HARASSMENT', 'SELF_HARM_RISK', 'PERSONAL_DATA', 'OTHER')),
+    details TEXT,
+    status TEXT NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'RESOLVED')),
+    queue_item_id UUID REFERENCES moderation_queue(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    CHECK ((target_request_id IS NULL) <> (target_user_id IS NULL))
+);
+
+-- A reporter has at most one open report per target.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_open_request
+    ON reports (reporter_id, target_request_id)
+    WHERE target_request_id IS NOT NULL AND status = 'OPEN';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_open_user
+    ON reports (reporter_id, target_user_id)
+    WHERE target_user_id IS NOT NULL AND status = 'OPEN';
+
+CREATE INDEX IF NOT EXISTS idx_reports_queue_item_id ON reports (queue_item_id) WHERE status = 'OPEN';
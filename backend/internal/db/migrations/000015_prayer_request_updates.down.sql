@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_prayer_actions_request_user;
@@ -0,0 +1,3 @@
+-- Used to find everyone who prayed for a request when it gets an update.
+CREATE INDEX IF NOT EXISTS idx_prayer_actions_request_user
+    ON prayer_actions (prayer_request_id, user_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_prayer_requests_answered;
+
+ALTER TABLE prayer_requests DROP CONSTRAINT IF EXISTS prayer_requests_resolution_check;
+ALTER TABLE prayer_requests
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS testimony,
+    DROP COLUMN IF EXISTS resolution;
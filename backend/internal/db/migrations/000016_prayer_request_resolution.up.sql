@@ -0,0 +1,16 @@
+ALTER TABLE prayer_requests
+    ADD COLUMN IF NOT EXISTS resolution TEXT,
+    ADD COLUMN IF NOT EXISTS testimony TEXT,
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'prayer_requests_resolution_check') THEN
+        ALTER TABLE prayer_requests
+            ADD CONSTRAINT prayer_requests_resolution_check CHECK (resolution IN ('ANSWERED', 'CLOSED'));
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_prayer_requests_answered
+    ON prayer_requests (created_at DESC)
+    WHERE status = 'CLOSED' AND resolution = 'ANSWERED' AND deleted_at IS NULL;
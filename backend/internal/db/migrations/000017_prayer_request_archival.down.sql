@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_prayer_requests_active_last_activity;
+
+ALTER TABLE prayer_requests
+    DROP COLUMN IF EXISTS archived_at,
+    DROP COLUMN IF EXISTS archive_warned_at,
+    DROP COLUMN IF EXISTS last_activity_at;
@@ -0,0 +1,20 @@
+ALTER TABLE prayer_requests
+    ADD COLUMN IF NOT EXISTS last_activity_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS archive_warned_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+UPDATE prayer_requests pr
+SET last_activity_at = GREATEST(
+    pr.updated_at,
+    COALESCE((SELECT MAX(pa.created_at) FROM prayer_actions pa WHERE pa.prayer_request_id = pr.id), pr.updated_at),
+    COALESCE((SELECT MAX(pu.created_at) FROM prayer_request_updates pu WHERE pu.prayer_request_id = pr.id), pr.updated_at)
+)
+WHERE pr.last_activity_at IS NULL;
+
+ALTER TABLE prayer_requests ALTER COLUMN last_activity_at SET DEFAULT NOW();
+ALTER TABLE prayer_requests ALTER COLUMN last_activity_at SET NOT NULL;
+
+-- The archival job scans live requests by how long they have been idle.
+CREATE INDEX IF NOT EXISTS idx_prayer_requests_active_last_activity
+    ON prayer_requests (last_activity_at)
+    WHERE status = 'ACTIVE' AND deleted_at IS NULL;
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_moderation_queue_open_comment;
+
+DELETE FROM moderation_queue WHERE comment_id IS NOT NULL;
+
+ALTER TABLE moderation_queue DROP CONSTRAINT IF EXISTS moderation_queue_target_check;
+ALTER TABLE moderation_queue
+    ADD CONSTRAINT moderation_queue_target_check
+    CHECK (prayer_request_id IS NOT NULL OR target_user_id IS NOT NULL);
+
+ALTER TABLE moderation_queue DROP COLUMN IF EXISTS comment_id;
+
+DROP TABLE IF EXISTS prayer_request_comments;
+
+ALTER TABLE prayer_requests DROP COLUMN IF EXISTS comments_enabled;
@@ -0,0 +1,33 @@
+ALTER TABLE prayer_requests ADD COLUMN IF NOT EXISTS comments_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE TABLE IF NOT EXISTS prayer_request_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    prayer_request_id UUID NOT NULL REFERENCES prayer_requests(id),
+    author_id UUID NOT NULL REFERENCES users(id),
+    parent_id UUID REFERENCES prayer_request_comments(id),
+    body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'VISIBLE' CHECK (status IN ('PENDING_REVIEW', 'VISIBLE', 'REMOVED')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_prayer_request_comments_request_created_at
+    ON prayer_request_comments (prayer_request_id, created_at)
+    WHERE parent_id IS NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_prayer_request_comments_parent_id
+    ON prayer_request_comments (parent_id)
+    WHERE parent_id IS NOT NULL AND deleted_at IS NULL;
+
+-- Queue items can also hold a comment for review in a moderated group.
+ALTER TABLE moderation_queue ADD COLUMN IF NOT EXISTS comment_id UUID REFERENCES prayer_request_comments(id);
+
+ALTER TABLE moderation_queue DROP CONSTRAINT IF EXISTS moderation_queue_target_check;
+ALTER TABLE moderation_queue
+    ADD CONSTRAINT moderation_queue_target_check
+    CHECK (prayer_request_id IS NOT NULL OR target_user_id IS NOT NULL OR comment_id IS NOT NULL);
+
+-- At most one open queue item per comment and group.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_queue_open_comment
+    ON moderation_queue (comment_id, group_id)
+    WHERE comment_id IS NOT NULL AND status <> 'RESOLVED';
@@ -0,0 +1,10 @@
+CREATE INDEX IF NOT EXISTS idx_prayer_requests_tradition_public_feed
+    ON prayer_requests (tradition, status, created_at DESC)
+    WHERE visibility = 'PUBLIC' AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_prayer_requests_tradition_created_at
+    ON prayer_requests (tradition, created_at DESC)
+    WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_prayer_requests_tradition_public_feed_keyset;
+DROP INDEX IF EXISTS idx_prayer_requests_tradition_created_at_keyset;
@@ -0,0 +1,11 @@
+-- Feeds page by (created_at, id); the id tiebreaker lets keyset scans use the index.
+CREATE INDEX IF NOT EXISTS idx_prayer_requests_tradition_public_feed_keyset
+    ON prayer_requests (tradition, status, created_at DESC, id DESC)
+    WHERE visibility = 'PUBLIC' AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_prayer_requests_tradition_created_at_keyset
+    ON prayer_requests (tradition, created_at DESC, id DESC)
+    WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_prayer_requests_tradition_public_feed;
+DROP INDEX IF EXISTS idx_prayer_requests_tradition_created_at;
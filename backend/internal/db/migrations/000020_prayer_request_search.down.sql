@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_prayer_requests_search_vector;
+
+ALTER TABLE prayer_requests DROP COLUMN IF EXISTS search_vector;
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS portuguese_unaccent;
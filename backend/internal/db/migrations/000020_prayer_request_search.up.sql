@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- Portuguese stemming that also folds accents, so "oracao" finds "oração".
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'portuguese_unaccent') THEN
+        CREATE TEXT SEARCH CONFIGURATION portuguese_unaccent (COPY = portuguese);
+        ALTER TEXT SEARCH CONFIGURATION portuguese_unaccent
+            ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+    END IF;
+END $$;
+
+-- Title matches weigh more than body matches when ranking.
+ALTER TABLE prayer_requests
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('portuguese_unaccent'::regconfig, coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('portuguese_unaccent'::regconfig, coalesce(body, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_prayer_requests_search_vector
+    ON prayer_requests USING GIN (search_vector)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_locale_check;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+
+DROP INDEX IF EXISTS idx_notifications_email_outbox;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS email_last_error,
+    DROP COLUMN IF EXISTS email_next_attempt_at,
+    DROP COLUMN IF EXISTS email_attempts,
+    DROP COLUMN IF EXISTS email_skipped_at;
@@ -0,0 +1,27 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS sent_email_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS email_skipped_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS email_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS email_next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS email_last_error TEXT;
+
+-- Notifications created before email delivery existed are not worth mailing.
+UPDATE notifications
+SET email_skipped_at = NOW()
+WHERE sent_email_at IS NULL AND email_skipped_at IS NULL;
+
+-- The outbox worker only ever scans notifications still waiting for delivery.
+CREATE INDEX IF NOT EXISTS idx_notifications_email_outbox
+    ON notifications (email_next_attempt_at)
+    WHERE sent_email_at IS NULL AND email_skipped_at IS NULL;
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT 'pt';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'users_locale_check') THEN
+        ALTER TABLE users ADD CONSTRAINT users_locale_check CHECK (locale IN ('pt', 'en'));
+    END IF;
+END
+$$;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+    email_digest TEXT NOT NULL DEFAULT 'OFF' CHECK (email_digest IN ('OFF', 'DAILY', 'WEEKLY')),
+    timezone TEXT NOT NULL DEFAULT 'America/Sao_Paulo',
+    digest_last_sent_at TIMESTAMPTZ,
+    unsubscribe_token UUID NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The digest job only looks at users who asked for a digest.
+CREATE INDEX IF NOT EXISTS idx_notification_preferences_digest
+    ON notification_preferences (user_id)
+    WHERE email_digest <> 'OFF';
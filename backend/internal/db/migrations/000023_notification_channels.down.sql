@@ -0,0 +1,10 @@
+DELETE FROM notifications WHERE NOT in_app;
+ALTER TABLE notifications DROP COLUMN IF EXISTS in_app;
+
+DROP TABLE IF EXISTS notification_group_mutes;
+
+ALTER TABLE notification_preferences
+    DROP CONSTRAINT IF EXISTS notification_preferences_quiet_hours_check,
+    DROP COLUMN IF EXISTS quiet_hours_end,
+    DROP COLUMN IF EXISTS quiet_hours_start,
+    DROP COLUMN IF EXISTS type_channels;
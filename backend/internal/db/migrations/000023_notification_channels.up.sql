@@ -0,0 +1,27 @@
+-- type_channels maps a notification type to {"inApp","email","push"}; types
+-- missing from the map use every channel.
+ALTER TABLE notification_preferences
+    ADD COLUMN IF NOT EXISTS type_channels JSONB NOT NULL DEFAULT '{}'::jsonb,
+    ADD COLUMN IF NOT EXISTS quiet_hours_start TIME,
+    ADD COLUMN IF NOT EXISTS quiet_hours_end TIME;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'notification_preferences_quiet_hours_check') THEN
+        ALTER TABLE notification_preferences ADD CONSTRAINT notification_preferences_quiet_hours_check
+            CHECK ((quiet_hours_start IS NULL AND quiet_hours_end IS NULL)
+                OR (quiet_hours_start IS NOT NULL AND quiet_hours_end IS NOT NULL AND quiet_hours_start <> quiet_hours_end));
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS notification_group_mutes (
+    user_id UUID NOT NULL REFERENCES users(id),
+    group_id UUID NOT NULL REFERENCES groups(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, group_id)
+);
+
+-- Rows kept only to carry an email (or push) are hidden from the in-app list.
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS in_app BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_notifications_push_outbox;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS push_last_error,
+    DROP COLUMN IF EXISTS push_next_attempt_at,
+    DROP COLUMN IF EXISTS push_attempts,
+    DROP COLUMN IF EXISTS push_skipped_at,
+    DROP COLUMN IF EXISTS push_sent_at;
+
+DROP TABLE IF EXISTS push_subscriptions;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    endpoint TEXT NOT NULL UNIQUE,
+    p256dh TEXT NOT NULL,
+    auth TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_subscriptions_user ON push_subscriptions (user_id);
+
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS push_sent_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS push_skipped_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS push_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS push_next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS push_last_error TEXT;
+
+-- Nothing raised before push existed is worth pushing.
+UPDATE notifications
+SET push_skipped_at = NOW()
+WHERE push_sent_at IS NULL AND push_skipped_at IS NULL;
+
+-- The push worker only ever scans notifications still waiting for delivery.
+CREATE INDEX IF NOT EXISTS idx_notifications_push_outbox
+    ON notifications (push_next_attempt_at)
+    WHERE push_sent_at IS NULL AND push_skipped_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notifications_user_type_unread;
+DROP INDEX IF EXISTS idx_notifications_not_in_app;
+DROP INDEX IF EXISTS idx_notifications_read_at;
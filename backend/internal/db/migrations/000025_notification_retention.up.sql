@@ -0,0 +1,14 @@
+-- The retention job looks for notifications read long ago and for rows kept
+-- only for email or push.
+CREATE INDEX IF NOT EXISTS idx_notifications_read_at
+    ON notifications (read_at)
+    WHERE read_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_not_in_app
+    ON notifications (created_at)
+    WHERE NOT in_app;
+
+-- Per-type unread counts and type filters on a user's notifications.
+CREATE INDEX IF NOT EXISTS idx_notifications_user_type_unread
+    ON notifications (user_id, type)
+    WHERE read_at IS NULL;
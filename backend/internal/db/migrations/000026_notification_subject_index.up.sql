@@ -0,0 +1,3 @@
+-- Notifications about a friendship are removed with it.
+CREATE INDEX IF NOT EXISTS idx_notifications_subject
+    ON notifications (subject_type, subject_id);
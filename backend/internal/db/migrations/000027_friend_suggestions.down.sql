@@ -0,0 +1 @@
+DROP TABLE IF EXISTS friend_suggestion_dismissals;
@@ -0,0 +1,7 @@
+-- Suggestions a user dismissed never come back.
+CREATE TABLE IF NOT EXISTS friend_suggestion_dismissals (
+    user_id UUID NOT NULL REFERENCES users(id),
+    dismissed_user_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, dismissed_user_id)
+);
@@ -0,0 +1,14 @@
+-- Users without a row use models.DefaultPrivacySettings, and rows are always
+-- written with every setting, so the columns carry no defaults.
+CREATE TABLE IF NOT EXISTS privacy_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+    profile_visibility TEXT NOT NULL
+        CHECK (profile_visibility IN ('EVERYONE', 'FRIENDS', 'NOBODY')),
+    stats_visibility TEXT NOT NULL
+        CHECK (stats_visibility IN ('EVERYONE', 'FRIENDS', 'NOBODY')),
+    friend_requests TEXT NOT NULL
+        CHECK (friend_requests IN ('EVERYONE', 'FRIENDS_OF_FRIENDS', 'NOBODY')),
+    searchable BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1 @@
+ALTER TABLE prayer_requests DROP COLUMN IF EXISTS comment_count;
@@ -0,0 +1,13 @@
+-- Visible comments and replies per request, kept up to date as comments are
+-- published, removed or deleted so feeds do not count them on every page.
+ALTER TABLE prayer_requests ADD COLUMN IF NOT EXISTS comment_count BIGINT NOT NULL DEFAULT 0;
+
+UPDATE prayer_requests pr
+SET comment_count = c.total
+FROM (
+    SELECT prayer_request_id, COUNT(*) AS total
+    FROM prayer_request_comments
+    WHERE status = 'VISIBLE' AND deleted_at IS NULL
+    GROUP BY prayer_request_id
+) c
+WHERE c.prayer_request_id = pr.id;
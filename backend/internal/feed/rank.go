@@ -0,0 +1,89 @@
+// Package feed scores and orders the "For you" home feed.
+package feed
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"parish-viva/backend/internal/models"
+)
+
+// Weights tunes how each signal contributes to a candidate's score.
+type Weights struct {
+	// HalfLife is how long it takes a request's recency to halve.
+	HalfLife time.Duration
+	// Relation multiplies the score by how the viewer relates to the author.
+	Relation map[models.FeedRelation]float64
+	// Neglect boosts unprayed requests, shrinking as prayers accumulate.
+	Neglect float64
+	// AlreadyPrayed multiplies the score of requests the viewer prayed for.
+	AlreadyPrayed float64
+}
+
+// DefaultWeights favours friends over groups and own requests.
+var DefaultWeights = Weights{
+	HalfLife: 36 * time.Hour,
+	Relation: map[models.FeedRelation]float64{
+		models.FeedRelationFriend: 1.0,
+		models.FeedRelationGroup:  0.7,
+		models.FeedRelationOwn:    0.5,
+	},
+	Neglect:       1.0,
+	AlreadyPrayed: 0.4,
+}
+
+// Score rates one candidate at now; higher is more relevant.
+func Score(c models.FeedCandidate, now time.Time, w Weights) float64 {
+	age := now.Sub(c.CreatedAt)
+	if age < 0 {
+		age = 0
+	}
+	recency := 1.0
+	if w.HalfLife > 0 {
+		recency = math.Exp2(-float64(age) / float64(w.HalfLife))
+	}
+
+	relation, ok := w.Relation[c.Relation]
+	if !ok {
+		relation = 1.0
+	}
+
+	prayed := float64(c.PrayedCount)
+	if prayed < 0 {
+		prayed = 0
+	}
+	neglect := 1 + w.Neglect/(1+math.Log1p(prayed))
+
+	score := recency * relation * neglect
+	if c.ViewerPrayed {
+		score *= w.AlreadyPrayed
+	}
+	return score
+}
+
+// Rank orders by score, breaking ties in chronological feed order.
+func Rank(candidates []models.FeedCandidate, now time.Time, w Weights) []models.FeedCandidate {
+	type scored struct {
+		candidate models.FeedCandidate
+		score     float64
+	}
+	items := make([]scored, len(candidates))
+	for i, c := range candidates {
+		items[i] = scored{candidate: c, score: Score(c, now, w)}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].score != items[j].score {
+			return items[i].score > items[j].score
+		}
+		if !items[i].candidate.CreatedAt.Equal(items[j].candidate.CreatedAt) {
+			return items[i].candidate.CreatedAt.After(items[j].candidate.CreatedAt)
+		}
+		return items[i].candidate.RequestID > items[j].candidate.RequestID
+	})
+	ranked := make([]models.FeedCandidate, len(items))
+	for i := range items {
+		ranked[i] = items[i].candidate
+	}
+	return ranked
+}
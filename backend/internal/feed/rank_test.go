@@ -0,0 +1,164 @@
+package feed
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"parish-viva/backend/internal/models"
+)
+
+var now = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func candidate(id string, age time.Duration, relation models.FeedRelation, prayed int64, viewerPrayed bool) models.FeedCandidate {
+	return models.FeedCandidate{
+		RequestID:    id,
+		CreatedAt:    now.Add(-age),
+		Relation:     relation,
+		PrayedCount:  prayed,
+		ViewerPrayed: viewerPrayed,
+	}
+}
+
+func TestScore(t *testing.T) {
+	fresh := Score(candidate("a", 0, models.FeedRelationFriend, 0, false), now, DefaultWeights)
+	if fresh != 2 {
+		t.Fatalf("fresh unprayed friend request scores %v, want 2", fresh)
+	}
+
+	tests := []struct {
+		name string
+		c    models.FeedCandidate
+		want float64
+	}{
+		{"one half-life halves the score", candidate("a", 36*time.Hour, models.FeedRelationFriend, 0, false), fresh / 2},
+		{"two half-lives quarter it", candidate("a", 72*time.Hour, models.FeedRelationFriend, 0, false), fresh / 4},
+		{"future timestamps count as new", candidate("a", -time.Hour, models.FeedRelationFriend, 0, false), fresh},
+		{"group requests weigh 0.7", candidate("a", 0, models.FeedRelationGroup, 0, false), fresh * 0.7},
+		{"own requests weigh 0.5", candidate("a", 0, models.FeedRelationOwn, 0, false), fresh * 0.5},
+		{"unknown relations weigh 1", candidate("a", 0, models.FeedRelation("OTHER"), 0, false), fresh},
+		{"prayers shrink the neglect boost", candidate("a", 0, models.FeedRelationFriend, 10, false), 1 + 1/(1+math.Log1p(10))},
+		{"negative counts are treated as none", candidate("a", 0, models.FeedRelationFriend, -5, false), fresh},
+		{"already prayed weighs 0.4", candidate("a", 0, models.FeedRelationFriend, 0, true), fresh * 0.4},
+		{"signals multiply", candidate("a", 36*time.Hour, models.FeedRelationGroup, 0, true), fresh / 2 * 0.7 * 0.4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(tt.c, now, DefaultWeights); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Score = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreNeglectBoostDecreases(t *testing.T) {
+	prev := math.Inf(1)
+	for _, prayed := range []int64{0, 1, 2, 5, 20, 100, 1000} {
+		got := Score(candidate("a", 0, models.FeedRelationFriend, prayed, false), now, DefaultWeights)
+		if got >= prev {
+			t.Errorf("%d prayers score %v, not below %v", prayed, got, prev)
+		}
+		if got <= 1 {
+			t.Errorf("%d prayers score %v, the boost should never go away", prayed, got)
+		}
+		prev = got
+	}
+}
+
+func TestScoreWithoutHalfLife(t *testing.T) {
+	w := DefaultWeights
+	w.HalfLife = 0
+	old := Score(candidate("a", 365*24*time.Hour, models.FeedRelationFriend, 0, false), now, w)
+	if old != 2 {
+		t.Errorf("with no half-life a year-old request scores %v, want 2", old)
+	}
+}
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []models.FeedCandidate
+		want       string
+	}{
+		{
+			name: "newer first when otherwise equal",
+			candidates: []models.FeedCandidate{
+				candidate("old", 48*time.Hour, models.FeedRelationFriend, 0, false),
+				candidate("new", time.Hour, models.FeedRelationFriend, 0, false),
+			},
+			want: "new,old",
+		},
+		{
+			name: "friends above groups above own",
+			candidates: []models.FeedCandidate{
+				candidate("own", 0, models.FeedRelationOwn, 0, false),
+				candidate("group", 0, models.FeedRelationGroup, 0, false),
+				candidate("friend", 0, models.FeedRelationFriend, 0, false),
+			},
+			want: "friend,group,own",
+		},
+		{
+			name: "neglected requests rise above prayed-for ones",
+			candidates: []models.FeedCandidate{
+				candidate("popular", 0, models.FeedRelationFriend, 50, false),
+				candidate("neglected", 0, models.FeedRelationFriend, 0, false),
+			},
+			want: "neglected,popular",
+		},
+		{
+			name: "already prayed sinks below an older request",
+			candidates: []models.FeedCandidate{
+				candidate("prayed", 0, models.FeedRelationFriend, 0, true),
+				candidate("older", 24*time.Hour, models.FeedRelationFriend, 0, false),
+			},
+			want: "older,prayed",
+		},
+		{
+			name: "equal scores and times order by id, descending",
+			candidates: []models.FeedCandidate{
+				candidate("b", 0, models.FeedRelationFriend, 0, false),
+				candidate("c", 0, models.FeedRelationFriend, 0, false),
+				candidate("a", 0, models.FeedRelationFriend, 0, false),
+			},
+			want: "c,b,a",
+		},
+		{
+			name:       "empty",
+			candidates: nil,
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked := Rank(tt.candidates, now, DefaultWeights)
+			ids := make([]string, len(ranked))
+			for i, c := range ranked {
+				ids[i] = c.RequestID
+			}
+			if got := strings.Join(ids, ","); got != tt.want {
+				t.Errorf("Rank = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankIsStableAcrossInputOrder(t *testing.T) {
+	candidates := []models.FeedCandidate{
+		candidate("a", time.Hour, models.FeedRelationGroup, 3, false),
+		candidate("b", time.Hour, models.FeedRelationGroup, 3, false),
+		candidate("c", 2*time.Hour, models.FeedRelationFriend, 0, true),
+		candidate("d", 0, models.FeedRelationOwn, 1, false),
+	}
+	first := Rank(candidates, now, DefaultWeights)
+	reversed := make([]models.FeedCandidate, len(candidates))
+	for i, c := range candidates {
+		reversed[len(candidates)-1-i] = c
+	}
+	second := Rank(reversed, now, DefaultWeights)
+	for i := range first {
+		if first[i].RequestID != second[i].RequestID {
+			t.Fatalf("order depends on input: %v vs %v", first, second)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"parish-viva/backend/internal/http/middleware"
+	"parish-viva/backend/internal/http/shared"
+	"parish-viva/backend/internal/repositories"
+	"parish-viva/backend/internal/services"
+)
+
+type BlockHandler struct {
+	service *services.Service
+}
+
+func NewBlockHandler(service *services.Service) *BlockHandler {
+	return &BlockHandler{service: service}
+}
+
+func (h *BlockHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	items, err := h.service.ListBlockedUsers(r.Context(), userID)
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *BlockHandler) Block(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	blocked, err := h.service.BlockUser(r.Context(), userID, chi.URLParam(r, "username"))
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "USER_NOT_FOUND", "User not found", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrCannotBlockSelf) {
+			shared.WriteError(w, http.StatusBadRequest, "CANNOT_BLOCK_SELF", "You cannot block yourself", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, blocked)
+}
+
+func (h *BlockHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	if err := h.service.UnblockUser(r.Context(), userID, chi.URLParam(r, "username")); err != nil {
+		if errors.Is(err, repositories.ErrBlockNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "BLOCK_NOT_FOUND", "Block not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "deleted"})
+}
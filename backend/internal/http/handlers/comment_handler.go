@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"parish-viva/backend/internal/http/middleware"
+	"parish-viva/backend/internal/http/shared"
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+	"parish-viva/backend/internal/services"
+)
+
+type CommentHandler struct {
+	service *services.Service
+}
+
+type createCommentRequest struct {
+	Body     string `json:"body"`
+	ParentID string `json:"parentId"`
+}
+
+func NewCommentHandler(service *services.Service) *CommentHandler {
+	return &CommentHandler{service: service}
+}
+
+func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	var req createCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	comment, err := h.service.CreateComment(r.Context(), models.CreateCommentInput{
+		RequestID: chi.URLParam(r, "id"),
+		AuthorID:  userID,
+		ParentID:  req.ParentID,
+		Body:      req.Body,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCommentBody) || errors.Is(err, repositories.ErrInvalidCommentParent) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPrayerRequestNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "REQUEST_NOT_FOUND", "Prayer request not found", nil)
+			return
+		}
+		if errors.Is(err, services.ErrCommentsDisabled) {
+			shared.WriteError(w, http.StatusForbidden, "COMMENTS_DISABLED", "The author turned off comments on this request", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPrayerRequestNotActive) {
+			shared.WriteError(w, http.StatusConflict, "REQUEST_NOT_ACTIVE", "This request is not open for comments", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, comment)
+}
+
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	limit, offset, page := parseFeedPagination(r)
+	items, total, err := h.service.ListComments(r.Context(), userID, chi.URLParam(r, "id"), limit, offset)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPrayerRequestNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "REQUEST_NOT_FOUND", "Prayer request not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{
+		"items":      items,
+		"pagination": newFeedPagination(page, limit, total),
+	})
+}
+
+func (h *CommentHandler) ListReplies(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	limit, offset, page := parseFeedPagination(r)
+	items, total, err := h.service.ListCommentReplies(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "commentId"), limit, offset)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPrayerRequestNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "REQUEST_NOT_FOUND", "Prayer request not found", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrCommentNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "COMMENT_NOT_FOUND", "Comment not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{
+		"items":      items,
+		"pagination": newFeedPagination(page, limit, total),
+	})
+}
+
+func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	err := h.service.DeleteComment(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "commentId"))
+	if err != nil {
+		if errors.Is(err, repositories.ErrCommentNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "COMMENT_NOT_FOUND", "Comment not found", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrCommentForbidden) {
+			shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "Only the comment or request author can delete this comment", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "deleted"})
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"parish-viva/backend/internal/http/shared"
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/services"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+var errInvalidIncludeTotal = errors.New("includeTotal must be true or false")
+var errInvalidAnswered = errors.New("answered must be true or false")
+var errInvalidHasUpdates = errors.New("hasUpdates must be true or false")
+var errInvalidGroupIDs = errors.New("invalid groupIds")
+var errInvalidCreatedAfter = errors.New("invalid createdAfter")
+var errInvalidCreatedBefore = errors.New("invalid createdBefore")
+var errInvalidSort = errors.New("sort must be recent or relevant")
+var errCursorWithRelevantSort = errors.New("cursor cannot be combined with sort=relevant")
+var errInvalidUnread = errors.New("unread must be true or false")
+
+// feedQuery pages by ?limit=&offset= or by ?cursor=.
+type feedQuery struct {
+	page       models.FeedPage
+	pageNumber int
+	// includeTotal defaults on for offset paging and off for cursors.
+	includeTotal bool
+}
+
+func parseFeedQuery(r *http.Request) (feedQuery, error) {
+	limit, offset, pageNumber := parseFeedPagination(r)
+	q := feedQuery{
+		page:         models.FeedPage{Limit: limit, Offset: offset},
+		pageNumber:   pageNumber,
+		includeTotal: true,
+	}
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		cursor, err := decodeFeedCursor(raw)
+		if err != nil {
+			return feedQuery{}, err
+		}
+		q.page.After = &cursor
+		q.page.Offset = 0
+		q.pageNumber = 0
+		q.includeTotal = false
+	}
+	if raw := r.URL.Query().Get("includeTotal"); raw != "" {
+		include, err := strconv.ParseBool(raw)
+		if err != nil {
+			return feedQuery{}, errInvalidIncludeTotal
+		}
+		q.includeTotal = include
+	}
+	return q, nil
+}
+
+// parseHomeFeedSort reads ?sort=recent|relevant; relevant pages by offset only.
+func parseHomeFeedSort(r *http.Request, q feedQuery) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(r.URL.Query().Get("sort"))) {
+	case "", "recent":
+		return false, nil
+	case "relevant":
+		if q.page.After != nil {
+			return false, errCursorWithRelevantSort
+		}
+		return true, nil
+	default:
+		return false, errInvalidSort
+	}
+}
+
+// parseFeedFilter reads the optional filters shared by the feeds and search.
+func parseFeedFilter(r *http.Request) (models.FeedFilter, error) {
+	query := r.URL.Query()
+	var filter models.FeedFilter
+	var err error
+	if filter.AnsweredOnly, err = parseOptionalBool(query.Get("answered")); err != nil {
+		return models.FeedFilter{}, errInvalidAnswered
+	}
+	for _, status := range queryList(query, "status") {
+		filter.Statuses = append(filter.Statuses, models.PrayerStatus(strings.ToUpper(status)))
+	}
+	for _, category := range queryList(query, "category") {
+		filter.Categories = append(filter.Categories, models.PrayerCategory(strings.ToUpper(category)))
+	}
+	for _, groupID := range queryList(query, "groupIds") {
+		if _, err = uuid.Parse(groupID); err != nil {
+			return models.FeedFilter{}, errInvalidGroupIDs
+		}
+		filter.GroupIDs = append(filter.GroupIDs, groupID)
+	}
+	filter.AuthorUsername = strings.TrimSpace(query.Get("authorUsername"))
+	if filter.CreatedAfter, err = parseFeedDate(query.Get("createdAfter"), false); err != nil {
+		return models.FeedFilter{}, errInvalidCreatedAfter
+	}
+	if filter.CreatedBefore, err = parseFeedDate(query.Get("createdBefore"), true); err != nil {
+		return models.FeedFilter{}, errInvalidCreatedBefore
+	}
+	if raw := strings.TrimSpace(query.Get("hasUpdates")); raw != "" {
+		hasUpdates, err := strconv.ParseBool(raw)
+		if err != nil {
+			return models.FeedFilter{}, errInvalidHasUpdates
+		}
+		filter.HasUpdates = &hasUpdates
+	}
+	return filter, nil
+}
+
+// isFeedFilterError reports whether the service rejected the feed filter.
+func isFeedFilterError(err error) bool {
+	return errors.Is(err, services.ErrInvalidFeedStatus) ||
+		errors.Is(err, services.ErrAnsweredWithStatus) ||
+		errors.Is(err, services.ErrInvalidCategory) ||
+		errors.Is(err, services.ErrInvalidDateRange)
+}
+
+// queryList reads name=a,b or name=a&name=b, dropping blanks.
+func queryList(query url.Values, name string) []string {
+	var values []string
+	for _, raw := range query[name] {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+func parseOptionalBool(value string) (bool, error) {
+	if value = strings.TrimSpace(value); value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// parseFeedDate moves a bare endOfDay date to the next midnight.
+func parseFeedDate(value string, endOfDay bool) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// writeFeedQueryError reports a malformed cursor, includeTotal or filter.
+func writeFeedQueryError(w http.ResponseWriter, err error) {
+	shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+}
+
+func writeFeedResponse(w http.ResponseWriter, items []models.PrayerRequest, next *models.FeedCursor, q feedQuery, total int64) {
+	body := map[string]any{
+		"items":      items,
+		"nextCursor": encodeFeedCursor(next),
+	}
+	if q.includeTotal {
+		body["pagination"] = newFeedPagination(q.pageNumber, q.page.Limit, total)
+	}
+	shared.WriteJSON(w, http.StatusOK, body)
+}
+
+// encodeFeedCursor keeps nil so the last page reports "nextCursor": null.
+func encodeFeedCursor(c *models.FeedCursor) *string {
+	if c == nil {
+		return nil
+	}
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(raw))
+	return &encoded
+}
+
+func decodeFeedCursor(value string) (models.FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return models.FeedCursor{}, errInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return models.FeedCursor{}, errInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return models.FeedCursor{}, errInvalidCursor
+	}
+	if _, err = uuid.Parse(id); err != nil {
+		return models.FeedCursor{}, errInvalidCursor
+	}
+	return models.FeedCursor{CreatedAt: t, ID: id}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
@@ -53,6 +54,20 @@ func (h *FriendHandler) ListPendingRequests(w http.ResponseWriter, r *http.Reque
 	shared.WriteJSON(w, http.StatusOK, map[string]any{"items": items})
 }
 
+func (h *FriendHandler) ListOutgoingRequests(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	items, err := h.service.ListOutgoingFriendRequests(r.Context(), userID)
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
 func (h *FriendHandler) SendRequest(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
 	if err := ensureAuthUser(h.service, r); err != nil {
@@ -83,6 +98,10 @@ func (h *FriendHandler) SendRequest(w http.ResponseWriter, r *http.Request) {
 			shared.WriteError(w, http.StatusConflict, "FRIEND_REQUEST_EXISTS", "Friend request already exists", nil)
 			return
 		}
+		if errors.Is(err, repositories.ErrFriendRequestsNotAllowed) {
+			shared.WriteError(w, http.StatusForbidden, "FRIEND_REQUESTS_NOT_ALLOWED", "This user is not accepting friend requests", nil)
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
@@ -109,6 +128,93 @@ func (h *FriendHandler) AcceptRequest(w http.ResponseWriter, r *http.Request) {
 	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "accepted"})
 }
 
+func (h *FriendHandler) RejectRequest(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	requestID := chi.URLParam(r, "requestId")
+	if err := h.service.RejectFriendRequest(r.Context(), userID, requestID); err != nil {
+		if errors.Is(err, repositories.ErrFriendRequestNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "FRIEND_REQUEST_NOT_FOUND", "Friend request not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "rejected"})
+}
+
+// CancelRequest withdraws a request the user sent.
+func (h *FriendHandler) CancelRequest(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	requestID := chi.URLParam(r, "requestId")
+	if err := h.service.CancelFriendRequest(r.Context(), userID, requestID); err != nil {
+		if errors.Is(err, repositories.ErrFriendRequestNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "FRIEND_REQUEST_NOT_FOUND", "Friend request not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "cancelled"})
+}
+
+func (h *FriendHandler) RemoveFriend(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	friendUserID := chi.URLParam(r, "userId")
+	if err := h.service.RemoveFriend(r.Context(), userID, friendUserID); err != nil {
+		if errors.Is(err, repositories.ErrFriendNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "FRIEND_NOT_FOUND", "Friend not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "deleted"})
+}
+
+func (h *FriendHandler) ListSuggestions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	items, err := h.service.ListFriendSuggestions(r.Context(), userID, limit)
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *FriendHandler) DismissSuggestion(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	if err := h.service.DismissFriendSuggestion(r.Context(), userID, chi.URLParam(r, "userId")); err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "USER_NOT_FOUND", "User not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "dismissed"})
+}
+
 func (h *FriendHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
 	if err := ensureAuthUser(h.service, r); err != nil {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -27,10 +28,17 @@ type createGroupRequest struct {
 }
 
 type updateGroupRequest struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	ImageURL    *string `json:"imageUrl"`
-	JoinPolicy  *string `json:"joinPolicy"`
+	Name               *string `json:"name"`
+	Description        *string `json:"description"`
+	ImageURL           *string `json:"imageUrl"`
+	JoinPolicy         *string `json:"joinPolicy"`
+	RequiresModeration *bool   `json:"requiresModeration"`
+}
+
+type banMemberRequest struct {
+	UserID    string     `json:"userId"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expiresAt"`
 }
 
 type changeMemberRoleRequest struct {
@@ -108,6 +116,10 @@ func (h *GroupHandler) RequestJoin(w http.ResponseWriter, r *http.Request) {
 			shared.WriteError(w, http.StatusForbidden, "GROUP_INVITE_ONLY", "This group is invite only", nil)
 			return
 		}
+		if errors.Is(err, repositories.ErrGroupBanned) {
+			shared.WriteError(w, http.StatusForbidden, "GROUP_BANNED", "You are banned from this group", nil)
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
@@ -281,9 +293,10 @@ func (h *GroupHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	in := models.UpdateGroupInput{
-		Name:        req.Name,
-		Description: req.Description,
-		ImageURL:    req.ImageURL,
+		Name:               req.Name,
+		Description:        req.Description,
+		ImageURL:           req.ImageURL,
+		RequiresModeration: req.RequiresModeration,
 	}
 	if req.JoinPolicy != nil {
 		jp := models.GroupJoinPolicy(*req.JoinPolicy)
@@ -316,30 +329,54 @@ func (h *GroupHandler) ListGroupFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	groupID := chi.URLParam(r, "id")
-	limit, offset, page := parseFeedPagination(r)
-	items, err := h.service.ListPrayerRequestsByGroup(r.Context(), viewerID, groupID, limit, offset)
+	q, err := parseFeedQuery(r)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
+	filter, err := parseFeedFilter(r)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
+	items, next, err := h.service.ListPrayerRequestsByGroup(r.Context(), viewerID, groupID, filter, q.page)
 	if err != nil {
 		if errors.Is(err, services.ErrPermissionDenied) {
 			shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "You must be a member to view this group's feed", nil)
 			return
 		}
+		if errors.Is(err, repositories.ErrGroupBanned) {
+			shared.WriteError(w, http.StatusForbidden, "GROUP_BANNED", "You are banned from this group", nil)
+			return
+		}
 		if errors.Is(err, repositories.ErrGroupNotFound) {
 			shared.WriteError(w, http.StatusNotFound, "GROUP_NOT_FOUND", "Group not found", nil)
 			return
 		}
+		if isFeedFilterError(err) {
+			writeFeedQueryError(w, err)
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
-	total, err := h.service.CountPrayerRequestsByGroup(r.Context(), viewerID, groupID)
-	if err != nil {
-		if errors.Is(err, services.ErrPermissionDenied) {
-			shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "You must be a member to view this group's feed", nil)
+	var total int64
+	if q.includeTotal {
+		total, err = h.service.CountPrayerRequestsByGroup(r.Context(), viewerID, groupID, filter)
+		if err != nil {
+			if errors.Is(err, services.ErrPermissionDenied) {
+				shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "You must be a member to view this group's feed", nil)
+				return
+			}
+			if errors.Is(err, repositories.ErrGroupBanned) {
+				shared.WriteError(w, http.StatusForbidden, "GROUP_BANNED", "You are banned from this group", nil)
+				return
+			}
+			shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 			return
 		}
-		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
-		return
 	}
-	writeFeedResponse(w, items, page, limit, total)
+	writeFeedResponse(w, items, next, q, total)
 }
 
 func (h *GroupHandler) ApproveJoinRequest(w http.ResponseWriter, r *http.Request) {
@@ -360,6 +397,10 @@ func (h *GroupHandler) ApproveJoinRequest(w http.ResponseWriter, r *http.Request
 			shared.WriteError(w, http.StatusNotFound, "JOIN_REQUEST_NOT_FOUND", "Join request not found", nil)
 			return
 		}
+		if errors.Is(err, repositories.ErrGroupBanned) {
+			shared.WriteError(w, http.StatusConflict, "GROUP_BANNED", "This user is banned from the group", nil)
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
@@ -389,3 +430,97 @@ func (h *GroupHandler) RejectJoinRequest(w http.ResponseWriter, r *http.Request)
 	}
 	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "rejected"})
 }
+
+func (h *GroupHandler) ListBans(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	groupID := chi.URLParam(r, "id")
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	items, total, err := h.service.ListGroupBans(r.Context(), actorID, groupID, limit, offset)
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "Group moderator access required", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{
+		"items": items,
+		"total": total,
+	})
+}
+
+func (h *GroupHandler) Ban(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	groupID := chi.URLParam(r, "id")
+	var req banMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	if req.UserID == "" {
+		shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "userId required", nil)
+		return
+	}
+	ban, err := h.service.BanGroupMember(r.Context(), models.CreateGroupBanInput{
+		GroupID:     groupID,
+		UserID:      req.UserID,
+		ActorUserID: actorID,
+		Reason:      req.Reason,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrDecisionReasonRequired) || errors.Is(err, services.ErrInvalidDecisionReason) || errors.Is(err, services.ErrInvalidBanExpiry) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, services.ErrCannotTargetSelf) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "You cannot ban yourself", nil)
+			return
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "You don't have permission to ban this user", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "USER_NOT_FOUND", "User not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, ban)
+}
+
+func (h *GroupHandler) Unban(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	groupID := chi.URLParam(r, "id")
+	targetUserID := chi.URLParam(r, "userId")
+	err := h.service.UnbanGroupMember(r.Context(), actorID, groupID, targetUserID)
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "Group moderator access required", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrGroupBanNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "BAN_NOT_FOUND", "Ban not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "unbanned"})
+}
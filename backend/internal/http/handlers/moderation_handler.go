@@ -1,17 +1,195 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 
+	"github.com/go-chi/chi/v5"
+
+	"parish-viva/backend/internal/http/middleware"
 	"parish-viva/backend/internal/http/shared"
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+	"parish-viva/backend/internal/services"
 )
 
-type ModerationHandler struct{}
+type ModerationHandler struct {
+	service *services.Service
+}
+
+type moderationDecisionRequest struct {
+	Reason string `json:"reason"`
+	Note   string `json:"note"`
+}
+
+func NewModerationHandler(service *services.Service) *ModerationHandler {
+	return &ModerationHandler{service: service}
+}
+
+func (h *ModerationHandler) Queue(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	limit, offset, page := parseFeedPagination(r)
+	query := r.URL.Query()
+	filter := models.ModerationQueueFilter{
+		GroupID: strings.TrimSpace(query.Get("groupId")),
+		Reason:  models.ModerationReason(strings.ToUpper(strings.TrimSpace(query.Get("reason")))),
+		Limit:   limit,
+		Offset:  offset,
+	}
+	for _, status := range strings.Split(query.Get("status"), ",") {
+		status = strings.ToUpper(strings.TrimSpace(status))
+		if status != "" {
+			filter.Statuses = append(filter.Statuses, models.ModerationQueueStatus(status))
+		}
+	}
+
+	items, total, err := h.service.ListModerationQueue(r.Context(), userID, filter)
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "Moderator access required", nil)
+			return
+		}
+		if errors.Is(err, services.ErrInvalidModerationStatus) || errors.Is(err, services.ErrInvalidModerationReason) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{
+		"items":      items,
+		"pagination": newFeedPagination(page, limit, total),
+	})
+}
+
+func (h *ModerationHandler) Claim(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	item, err := h.service.ClaimModerationItem(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeModerationError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, item)
+}
+
+func (h *ModerationHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, func(userID, itemID string, req moderationDecisionRequest) (models.ModerationAction, error) {
+		return h.service.ApproveModerationItem(r.Context(), userID, itemID, req.Note)
+	})
+}
+
+func (h *ModerationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, func(userID, itemID string, req moderationDecisionRequest) (models.ModerationAction, error) {
+		return h.service.RejectModerationItem(r.Context(), userID, itemID, req.Reason, req.Note)
+	})
+}
+
+func (h *ModerationHandler) RequestChanges(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, func(userID, itemID string, req moderationDecisionRequest) (models.ModerationAction, error) {
+		return h.service.RequestChangesOnModerationItem(r.Context(), userID, itemID, req.Reason, req.Note)
+	})
+}
+
+// Dismiss closes a user report without further action.
+func (h *ModerationHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, func(userID, itemID string, req moderationDecisionRequest) (models.ModerationAction, error) {
+		return h.service.DismissModerationItem(r.Context(), userID, itemID, req.Note)
+	})
+}
+
+// RemoveRequest serves POST /requests/{id}/remove.
+func (h *ModerationHandler) RemoveRequest(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, func(userID, requestID string, req moderationDecisionRequest) (models.ModerationAction, error) {
+		return h.service.RemovePrayerRequest(r.Context(), userID, requestID, req.Reason, req.Note)
+	})
+}
+
+func (h *ModerationHandler) RevertAction(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	action, err := h.service.RevertModerationAction(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeModerationError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, action)
+}
+
+// ListRequestActions serves GET /requests/{id}/moderation-actions.
+func (h *ModerationHandler) ListRequestActions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	items, err := h.service.ListModerationActions(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeModerationError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"items": items})
+}
 
-func NewModerationHandler() *ModerationHandler {
-	return &ModerationHandler{}
+func (h *ModerationHandler) decide(w http.ResponseWriter, r *http.Request, apply func(userID, id string, req moderationDecisionRequest) (models.ModerationAction, error)) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	var req moderationDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	action, err := apply(userID, chi.URLParam(r, "id"), req)
+	if err != nil {
+		writeModerationError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, action)
 }
 
-func (h *ModerationHandler) Queue(w http.ResponseWriter, _ *http.Request) {
-	shared.WriteJSON(w, http.StatusOK, map[string]any{"items": []any{}})
+func writeModerationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrDecisionReasonRequired), errors.Is(err, services.ErrInvalidDecisionReason), errors.Is(err, services.ErrInvalidDecisionNote):
+		shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+	case errors.Is(err, repositories.ErrPrayerRequestNotFound):
+		shared.WriteError(w, http.StatusNotFound, "REQUEST_NOT_FOUND", "Prayer request not found", nil)
+	case errors.Is(err, repositories.ErrCommentNotFound):
+		shared.WriteError(w, http.StatusNotFound, "COMMENT_NOT_FOUND", "Comment not found", nil)
+	case errors.Is(err, repositories.ErrModerationActionNotFound):
+		shared.WriteError(w, http.StatusNotFound, "MODERATION_ACTION_NOT_FOUND", "Moderation action not found", nil)
+	case errors.Is(err, repositories.ErrModerationActionNotRevertible):
+		shared.WriteError(w, http.StatusConflict, "MODERATION_ACTION_NOT_REVERTIBLE", "This action cannot be reverted", nil)
+	case errors.Is(err, repositories.ErrModerationActionReverted):
+		shared.WriteError(w, http.StatusConflict, "MODERATION_ACTION_REVERTED", "This action has already been reverted", nil)
+	case errors.Is(err, repositories.ErrModerationStateChanged):
+		shared.WriteError(w, http.StatusConflict, "MODERATION_STATE_CHANGED", "The request changed since this action; review it again instead", nil)
+	case errors.Is(err, services.ErrPermissionDenied):
+		shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "Moderator access required", nil)
+	case errors.Is(err, repositories.ErrModerationItemNotFound):
+		shared.WriteError(w, http.StatusNotFound, "MODERATION_ITEM_NOT_FOUND", "Moderation item not found", nil)
+	case errors.Is(err, repositories.ErrModerationItemClaimed):
+		shared.WriteError(w, http.StatusConflict, "MODERATION_ITEM_CLAIMED", "Another moderator is reviewing this item", nil)
+	case errors.Is(err, repositories.ErrModerationItemResolved):
+		shared.WriteError(w, http.StatusConflict, "MODERATION_ITEM_RESOLVED", "This item has already been resolved", nil)
+	case errors.Is(err, services.ErrModerationTargetMismatch):
+		shared.WriteError(w, http.StatusConflict, "MODERATION_TARGET_MISMATCH", "This action does not apply to this item", nil)
+	default:
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+	}
 }
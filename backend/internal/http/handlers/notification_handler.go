@@ -1,22 +1,29 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 
 	"parish-viva/backend/internal/http/middleware"
 	"parish-viva/backend/internal/http/shared"
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
 	"parish-viva/backend/internal/services"
 )
 
 type NotificationHandler struct {
-	service *services.Service
+	service   *services.Service
+	heartbeat time.Duration
 }
 
-func NewNotificationHandler(service *services.Service) *NotificationHandler {
-	return &NotificationHandler{service: service}
+func NewNotificationHandler(service *services.Service, heartbeat time.Duration) *NotificationHandler {
+	return &NotificationHandler{service: service, heartbeat: heartbeat}
 }
 
 func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
@@ -27,9 +34,23 @@ func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
 	}
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	var filter models.NotificationFilter
+	for _, t := range queryList(r.URL.Query(), "type") {
+		filter.Types = append(filter.Types, models.NotificationType(strings.ToUpper(t)))
+	}
+	unreadOnly, err := parseOptionalBool(r.URL.Query().Get("unread"))
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", errInvalidUnread.Error(), nil)
+		return
+	}
+	filter.UnreadOnly = unreadOnly
 
-	items, err := h.service.ListNotifications(r.Context(), userID, limit, offset)
+	items, err := h.service.ListNotifications(r.Context(), userID, filter, limit, offset)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidNotificationType) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
@@ -50,12 +71,12 @@ func (h *NotificationHandler) UnreadCount(w http.ResponseWriter, r *http.Request
 		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
 		return
 	}
-	count, err := h.service.CountUnreadNotifications(r.Context(), userID)
+	counts, err := h.service.UnreadNotificationCounts(r.Context(), userID)
 	if err != nil {
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
-	shared.WriteJSON(w, http.StatusOK, map[string]any{"count": count})
+	shared.WriteJSON(w, http.StatusOK, counts)
 }
 
 func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
@@ -84,3 +105,127 @@ func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request
 	}
 	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "read"})
 }
+
+func (h *NotificationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		shared.WriteError(w, http.StatusNotFound, "NOTIFICATION_NOT_FOUND", "Notification not found", nil)
+		return
+	}
+	if err := h.service.DeleteNotification(r.Context(), userID, id); err != nil {
+		if errors.Is(err, repositories.ErrNotificationNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "NOTIFICATION_NOT_FOUND", "Notification not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "deleted"})
+}
+
+// DeleteRead clears the user's read notifications.
+func (h *NotificationHandler) DeleteRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	deleted, err := h.service.DeleteReadNotifications(r.Context(), userID)
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "deleted", "deleted": deleted})
+}
+
+// Stream sends new notifications as server-sent events, replaying from Last-Event-ID.
+func (h *NotificationHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	lastEventID := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if lastEventID == "" {
+		lastEventID = strings.TrimSpace(r.URL.Query().Get("lastEventId"))
+	}
+	if _, err := uuid.Parse(lastEventID); err != nil {
+		lastEventID = ""
+	}
+
+	stream, err := h.service.OpenNotificationStream(userID)
+	if err != nil {
+		if errors.Is(err, services.ErrTooManyNotificationStreams) {
+			shared.WriteError(w, http.StatusTooManyRequests, "TOO_MANY_STREAMS", "Too many open notification streams", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	defer stream.Close()
+
+	ctx := r.Context()
+	sse := newSSEWriter(w)
+	w.WriteHeader(http.StatusOK)
+	if err = sse.retry(5 * time.Second); err != nil {
+		return
+	}
+	if lastEventID != "" {
+		missed, err := h.service.ListNotificationsAfter(ctx, userID, lastEventID)
+		if err != nil {
+			return
+		}
+		for _, n := range missed {
+			if err = sse.event("notification", n.ID, n); err != nil {
+				return
+			}
+		}
+	}
+	if err = h.sendUnreadCount(r, sse, userID); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err = sse.comment("ping"); err != nil {
+				return
+			}
+		case signal, ok := <-stream.Signals():
+			if !ok {
+				return
+			}
+			if signal.NotificationID != "" {
+				n, err := h.service.GetNotification(ctx, userID, signal.NotificationID)
+				if err == nil {
+					err = sse.event("notification", n.ID, n)
+				} else if errors.Is(err, repositories.ErrNotificationNotFound) {
+					err = nil
+				}
+				if err != nil {
+					return
+				}
+			}
+			if err = h.sendUnreadCount(r, sse, userID); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *NotificationHandler) sendUnreadCount(r *http.Request, sse *sseWriter, userID string) error {
+	count, err := h.service.CountUnreadNotifications(r.Context(), userID)
+	if err != nil {
+		return err
+	}
+	return sse.event("unread-count", "", map[string]any{"count": count})
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+
+	"parish-viva/backend/internal/http/middleware"
+	"parish-viva/backend/internal/http/shared"
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+	"parish-viva/backend/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type NotificationPreferencesHandler struct {
+	service *services.Service
+}
+
+type updateNotificationPreferencesRequest struct {
+	EmailEnabled  *bool                                            `json:"emailEnabled"`
+	EmailDigest   *models.DigestFrequency                          `json:"emailDigest"`
+	Timezone      *string                                          `json:"timezone"`
+	Types         map[models.NotificationType]notificationChannels `json:"types"`
+	QuietHours    *models.QuietHours                               `json:"quietHours"`
+	MutedGroupIDs *[]string                                        `json:"mutedGroupIds"`
+}
+
+// notificationChannels also accepts "off" for every channel off.
+type notificationChannels models.NotificationChannels
+
+func (c *notificationChannels) UnmarshalJSON(data []byte) error {
+	var shorthand string
+	if err := json.Unmarshal(data, &shorthand); err == nil {
+		if shorthand != "off" {
+			return errors.New("unknown channel shorthand")
+		}
+		*c = notificationChannels{}
+		return nil
+	}
+	channels := models.DefaultNotificationChannels
+	if err := json.Unmarshal(data, &channels); err != nil {
+		return err
+	}
+	*c = notificationChannels(channels)
+	return nil
+}
+
+func NewNotificationPreferencesHandler(service *services.Service) *NotificationPreferencesHandler {
+	return &NotificationPreferencesHandler{service: service}
+}
+
+func (h *NotificationPreferencesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	prefs, err := h.service.GetNotificationPreferences(r.Context(), userID)
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, prefs)
+}
+
+func (h *NotificationPreferencesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	var req updateNotificationPreferencesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	_, hasQuietHours := raw["quietHours"]
+	in := models.UpdateNotificationPreferencesInput{
+		EmailEnabled:  req.EmailEnabled,
+		EmailDigest:   req.EmailDigest,
+		Timezone:      req.Timezone,
+		QuietHours:    req.QuietHours,
+		SetQuietHours: hasQuietHours,
+		MutedGroupIDs: req.MutedGroupIDs,
+	}
+	if len(req.Types) > 0 {
+		in.Types = make(map[models.NotificationType]models.NotificationChannels, len(req.Types))
+		for t, channels := range req.Types {
+			in.Types[t] = models.NotificationChannels(channels)
+		}
+	}
+	prefs, err := h.service.UpdateNotificationPreferences(r.Context(), userID, in)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDigestFrequency) || errors.Is(err, services.ErrInvalidTimezone) ||
+			errors.Is(err, services.ErrInvalidNotificationType) || errors.Is(err, services.ErrInvalidQuietHours) ||
+			errors.Is(err, services.ErrInvalidMutedGroupID) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrGroupNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "GROUP_NOT_FOUND", "Group not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, prefs)
+}
+
+// unsubscribePage only unsubscribes on POST, so link prefetchers change nothing.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!doctype html>
+<html>
+<head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>Creo</title></head>
+<body>
+{{if .Done}}<p>Você não receberá mais emails do Creo.</p>
+<p>You will no longer receive emails from Creo.</p>
+{{else}}<p>Deixar de receber emails do Creo? / Stop receiving emails from Creo?</p>
+<form method="post">
+<input type="hidden" name="List-Unsubscribe" value="One-Click">
+<button type="submit">Cancelar inscrição / Unsubscribe</button>
+</form>
+{{end}}</body>
+</html>
+`))
+
+func writeUnsubscribePage(w http.ResponseWriter, done bool) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = unsubscribePage.Execute(w, struct{ Done bool }{done})
+}
+
+// ConfirmUnsubscribe only shows the confirmation page.
+func (h *NotificationPreferencesHandler) ConfirmUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	writeUnsubscribePage(w, false)
+}
+
+// Unsubscribe handles the confirmation form and one-click List-Unsubscribe posts.
+func (h *NotificationPreferencesHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.UnsubscribeFromEmails(r.Context(), chi.URLParam(r, "token")); err != nil {
+		if errors.Is(err, repositories.ErrUnsubscribeTokenNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "UNSUBSCRIBE_TOKEN_NOT_FOUND", "Unsubscribe link is invalid", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	if strings.Contains(r.Header.Get("Accept"), "text/html") {
+		writeUnsubscribePage(w, true)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "unsubscribed"})
+}
@@ -3,10 +3,13 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 
 	"parish-viva/backend/internal/http/middleware"
 	"parish-viva/backend/internal/http/shared"
@@ -21,21 +24,31 @@ type PrayerHandler struct {
 }
 
 type createPrayerRequest struct {
-	Title          string   `json:"title"`
-	Body           string   `json:"body"`
-	Category       string   `json:"category"`
-	Visibility     string   `json:"visibility"`
-	AllowAnonymous bool     `json:"allowAnonymous"`
-	GroupIDs       []string `json:"groupIds"`
+	Title           string   `json:"title"`
+	Body            string   `json:"body"`
+	Category        string   `json:"category"`
+	Visibility      string   `json:"visibility"`
+	AllowAnonymous  bool     `json:"allowAnonymous"`
+	GroupIDs        []string `json:"groupIds"`
+	CommentsEnabled *bool    `json:"commentsEnabled"`
 }
 
 type updatePrayerRequest struct {
-	Title          string   `json:"title"`
-	Body           string   `json:"body"`
-	Category       string   `json:"category"`
-	Visibility     string   `json:"visibility"`
-	AllowAnonymous bool     `json:"allowAnonymous"`
-	GroupIDs       []string `json:"groupIds"`
+	Title           string   `json:"title"`
+	Body            string   `json:"body"`
+	Category        string   `json:"category"`
+	Visibility      string   `json:"visibility"`
+	AllowAnonymous  bool     `json:"allowAnonymous"`
+	GroupIDs        []string `json:"groupIds"`
+	CommentsEnabled *bool    `json:"commentsEnabled"`
+}
+
+type createPrayerUpdateRequest struct {
+	Body string `json:"body"`
+}
+
+type resolvePrayerRequest struct {
+	Testimony string `json:"testimony"`
 }
 
 type prayRequest struct {
@@ -54,7 +67,16 @@ func NewPrayerHandler(service *services.Service, prayedWindowHour int) *PrayerHa
 }
 
 func (h *PrayerHandler) ListPublic(w http.ResponseWriter, r *http.Request) {
-	limit, offset, page := parseFeedPagination(r)
+	q, err := parseFeedQuery(r)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
+	filter, err := parseFeedFilter(r)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
 	viewerUserID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
 	if viewerUserID != "" {
 		if err := ensureAuthUser(h.service, r); err != nil {
@@ -62,77 +84,196 @@ func (h *PrayerHandler) ListPublic(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	items, err := h.service.ListPublicPrayerRequests(r.Context(), viewerUserID, limit, offset)
+	items, next, err := h.service.ListPublicPrayerRequests(r.Context(), viewerUserID, filter, q.page)
 	if err != nil {
+		if isFeedFilterError(err) {
+			writeFeedQueryError(w, err)
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
-	total, err := h.service.CountPublicPrayerRequests(r.Context(), viewerUserID)
-	if err != nil {
-		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
-		return
+	var total int64
+	if q.includeTotal {
+		total, err = h.service.CountPublicPrayerRequests(r.Context(), viewerUserID, filter)
+		if err != nil {
+			shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+			return
+		}
 	}
-	writeFeedResponse(w, items, page, limit, total)
+	writeFeedResponse(w, items, next, q, total)
 }
 
+// ListHome is newest first, or ranked with ?sort=relevant.
 func (h *PrayerHandler) ListHome(w http.ResponseWriter, r *http.Request) {
-	limit, offset, page := parseFeedPagination(r)
+	q, err := parseFeedQuery(r)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
+	filter, err := parseFeedFilter(r)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
+	relevant, err := parseHomeFeedSort(r, q)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
 	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
 	if err := ensureAuthUser(h.service, r); err != nil {
 		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
 		return
 	}
-	items, err := h.service.ListHomePrayerRequests(r.Context(), userID, limit, offset)
-	if err != nil {
-		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+	if relevant {
+		items, total, err := h.service.ListRelevantHomePrayerRequests(r.Context(), userID, filter, q.page.Limit, q.page.Offset)
+		if err != nil {
+			if isFeedFilterError(err) {
+				writeFeedQueryError(w, err)
+				return
+			}
+			shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+			return
+		}
+		writeFeedResponse(w, items, nil, q, total)
 		return
 	}
-	total, err := h.service.CountHomePrayerRequests(r.Context(), userID)
+	items, next, err := h.service.ListHomePrayerRequests(r.Context(), userID, filter, q.page)
 	if err != nil {
+		if isFeedFilterError(err) {
+			writeFeedQueryError(w, err)
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
-	writeFeedResponse(w, items, page, limit, total)
+	var total int64
+	if q.includeTotal {
+		total, err = h.service.CountHomePrayerRequests(r.Context(), userID, filter)
+		if err != nil {
+			shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+			return
+		}
+	}
+	writeFeedResponse(w, items, next, q, total)
 }
 
 func (h *PrayerHandler) ListGroupsFeed(w http.ResponseWriter, r *http.Request) {
-	limit, offset, page := parseFeedPagination(r)
+	q, err := parseFeedQuery(r)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
+	filter, err := parseFeedFilter(r)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
 	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
 	if err := ensureAuthUser(h.service, r); err != nil {
 		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
 		return
 	}
-	items, err := h.service.ListGroupsPrayerRequests(r.Context(), userID, limit, offset)
+	items, next, err := h.service.ListGroupsPrayerRequests(r.Context(), userID, filter, q.page)
 	if err != nil {
+		if isFeedFilterError(err) {
+			writeFeedQueryError(w, err)
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
-	total, err := h.service.CountGroupsPrayerRequests(r.Context(), userID)
-	if err != nil {
-		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
-		return
+	var total int64
+	if q.includeTotal {
+		total, err = h.service.CountGroupsPrayerRequests(r.Context(), userID, filter)
+		if err != nil {
+			shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+			return
+		}
 	}
-	writeFeedResponse(w, items, page, limit, total)
+	writeFeedResponse(w, items, next, q, total)
 }
 
 func (h *PrayerHandler) ListFriendsFeed(w http.ResponseWriter, r *http.Request) {
-	limit, offset, page := parseFeedPagination(r)
+	q, err := parseFeedQuery(r)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
+	filter, err := parseFeedFilter(r)
+	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
 	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
 	if err := ensureAuthUser(h.service, r); err != nil {
 		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
 		return
 	}
-	items, err := h.service.ListFriendsPrayerRequests(r.Context(), userID, limit, offset)
+	items, next, err := h.service.ListFriendsPrayerRequests(r.Context(), userID, filter, q.page)
 	if err != nil {
+		if isFeedFilterError(err) {
+			writeFeedQueryError(w, err)
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
-	total, err := h.service.CountFriendsPrayerRequests(r.Context(), userID)
+	var total int64
+	if q.includeTotal {
+		total, err = h.service.CountFriendsPrayerRequests(r.Context(), userID, filter)
+		if err != nil {
+			shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+			return
+		}
+	}
+	writeFeedResponse(w, items, next, q, total)
+}
+
+// Search takes the feed filters plus the single groupId it launched with.
+func (h *PrayerHandler) Search(w http.ResponseWriter, r *http.Request) {
+	viewerUserID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if viewerUserID != "" {
+		if err := ensureAuthUser(h.service, r); err != nil {
+			shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+			return
+		}
+	}
+	filter, err := parseFeedFilter(r)
 	if err != nil {
+		writeFeedQueryError(w, err)
+		return
+	}
+	if groupID := strings.TrimSpace(r.URL.Query().Get("groupId")); groupID != "" {
+		if _, err = uuid.Parse(groupID); err != nil {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid groupId", nil)
+			return
+		}
+		filter.GroupIDs = append(filter.GroupIDs, groupID)
+	}
+	limit, offset, page := parseFeedPagination(r)
+	in := models.SearchPrayerRequestsInput{
+		ViewerID: viewerUserID,
+		Query:    r.URL.Query().Get("q"),
+		Filter:   filter,
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	items, total, err := h.service.SearchPrayerRequests(r.Context(), in)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearchQuery) || isFeedFilterError(err) {
+			writeFeedQueryError(w, err)
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
 		return
 	}
-	writeFeedResponse(w, items, page, limit, total)
+	shared.WriteJSON(w, http.StatusOK, map[string]any{
+		"items":      items,
+		"pagination": newFeedPagination(page, limit, total),
+	})
 }
 
 func parseFeedPagination(r *http.Request) (int, int, int) {
@@ -148,20 +289,17 @@ func parseFeedPagination(r *http.Request) (int, int, int) {
 	return limit, offset, page
 }
 
-func writeFeedResponse(w http.ResponseWriter, items []models.PrayerRequest, page, pageSize int, total int64) {
+func newFeedPagination(page, pageSize int, total int64) feedPagination {
 	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
 	if totalPages == 0 {
 		totalPages = 1
 	}
-	shared.WriteJSON(w, http.StatusOK, map[string]any{
-		"items": items,
-		"pagination": feedPagination{
-			Page:       page,
-			PageSize:   pageSize,
-			Total:      total,
-			TotalPages: totalPages,
-		},
-	})
+	return feedPagination{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+	}
 }
 
 func (h *PrayerHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -177,15 +315,20 @@ func (h *PrayerHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	prayer, err := h.service.CreatePrayerRequest(r.Context(), models.CreatePrayerRequestInput{
-		AuthorID:       userID,
-		Title:          req.Title,
-		Body:           req.Body,
-		Category:       models.PrayerCategory(req.Category),
-		Visibility:     models.Visibility(req.Visibility),
-		AllowAnonymous: req.AllowAnonymous,
-		GroupIDs:       req.GroupIDs,
+		AuthorID:        userID,
+		Title:           req.Title,
+		Body:            req.Body,
+		Category:        models.PrayerCategory(req.Category),
+		Visibility:      models.Visibility(req.Visibility),
+		AllowAnonymous:  req.AllowAnonymous,
+		GroupIDs:        req.GroupIDs,
+		CommentsEnabled: req.CommentsEnabled,
 	})
 	if err != nil {
+		if errors.Is(err, repositories.ErrGroupBanned) {
+			shared.WriteError(w, http.StatusForbidden, "GROUP_BANNED", "You are banned from one of the selected groups", nil)
+			return
+		}
 		if errors.Is(err, repositories.ErrGroupAccessDenied) {
 			shared.WriteError(w, http.StatusForbidden, "GROUP_ACCESS_DENIED", "You can only post to groups where you are a member", nil)
 			return
@@ -233,16 +376,21 @@ func (h *PrayerHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	prayer, err := h.service.UpdatePrayerRequest(r.Context(), models.UpdatePrayerRequestInput{
-		RequestID:      chi.URLParam(r, "id"),
-		EditorID:       userID,
-		Title:          req.Title,
-		Body:           req.Body,
-		Category:       models.PrayerCategory(req.Category),
-		Visibility:     models.Visibility(req.Visibility),
-		AllowAnonymous: req.AllowAnonymous,
-		GroupIDs:       req.GroupIDs,
+		RequestID:       chi.URLParam(r, "id"),
+		EditorID:        userID,
+		Title:           req.Title,
+		Body:            req.Body,
+		Category:        models.PrayerCategory(req.Category),
+		Visibility:      models.Visibility(req.Visibility),
+		AllowAnonymous:  req.AllowAnonymous,
+		GroupIDs:        req.GroupIDs,
+		CommentsEnabled: req.CommentsEnabled,
 	})
 	if err != nil {
+		if errors.Is(err, repositories.ErrGroupBanned) {
+			shared.WriteError(w, http.StatusForbidden, "GROUP_BANNED", "You are banned from one of the selected groups", nil)
+			return
+		}
 		if errors.Is(err, repositories.ErrGroupAccessDenied) {
 			shared.WriteError(w, http.StatusForbidden, "GROUP_ACCESS_DENIED", "You can only post to groups where you are a member", nil)
 			return
@@ -261,6 +409,139 @@ func (h *PrayerHandler) Update(w http.ResponseWriter, r *http.Request) {
 	shared.WriteJSON(w, http.StatusOK, prayer)
 }
 
+func (h *PrayerHandler) CreateUpdate(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	var req createPrayerUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	update, err := h.service.CreatePrayerUpdate(r.Context(), userID, chi.URLParam(r, "id"), req.Body)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidUpdateBody) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPrayerRequestNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "REQUEST_NOT_FOUND", "Prayer request not found", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPrayerRequestForbidden) {
+			shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "Only the author can post updates on this request", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, update)
+}
+
+func (h *PrayerHandler) ListUpdates(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	limit, offset, page := parseFeedPagination(r)
+	items, total, err := h.service.ListPrayerUpdates(r.Context(), userID, chi.URLParam(r, "id"), limit, offset)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPrayerRequestNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "REQUEST_NOT_FOUND", "Prayer request not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{
+		"items":      items,
+		"pagination": newFeedPagination(page, limit, total),
+	})
+}
+
+func (h *PrayerHandler) MarkAnswered(w http.ResponseWriter, r *http.Request) {
+	var req resolvePrayerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	h.resolve(w, r, models.ResolutionAnswered, req.Testimony)
+}
+
+func (h *PrayerHandler) Close(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, models.ResolutionClosed, "")
+}
+
+func (h *PrayerHandler) resolve(w http.ResponseWriter, r *http.Request, resolution models.PrayerResolution, testimony string) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	requestID := chi.URLParam(r, "id")
+	err := h.service.ResolvePrayerRequest(r.Context(), models.ClosePrayerRequestInput{
+		RequestID:  requestID,
+		AuthorID:   userID,
+		Resolution: resolution,
+		Testimony:  testimony,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTestimony) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPrayerRequestNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "REQUEST_NOT_FOUND", "Prayer request not found", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPrayerRequestForbidden) {
+			shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "Only the author can close this request", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPrayerRequestNotActive) {
+			shared.WriteError(w, http.StatusConflict, "REQUEST_NOT_ACTIVE", "Only active requests can be closed", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	prayer, err := h.service.GetPrayerRequestByID(r.Context(), userID, requestID)
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, prayer)
+}
+
+func (h *PrayerHandler) KeepActive(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	prayer, err := h.service.KeepPrayerRequestActive(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repositories.ErrPrayerRequestNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "REQUEST_NOT_FOUND", "Prayer request not found", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPrayerRequestForbidden) {
+			shared.WriteError(w, http.StatusForbidden, "FORBIDDEN", "Only the author can keep this request active", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPrayerRequestNotActive) {
+			shared.WriteError(w, http.StatusConflict, "REQUEST_NOT_ACTIVE", "Only active or archived requests can be renewed", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, prayer)
+}
+
 func (h *PrayerHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
 	if err := ensureAuthUser(h.service, r); err != nil {
@@ -299,6 +580,14 @@ func (h *PrayerHandler) Pray(w http.ResponseWriter, r *http.Request) {
 			shared.WriteError(w, http.StatusTooManyRequests, "PRAYED_RATE_LIMITED", "You can pray again later for this request", nil)
 			return
 		}
+		if errors.Is(err, repositories.ErrPrayerRequestNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "REQUEST_NOT_FOUND", "Prayer request not found", nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPrayerRequestNotActive) {
+			shared.WriteError(w, http.StatusConflict, "REQUEST_NOT_ACTIVE", "This request is no longer accepting prayers", nil)
+			return
+		}
 		if errors.Is(err, services.ErrInvalidPrayerActionType) {
 			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid prayer action type", nil)
 			return
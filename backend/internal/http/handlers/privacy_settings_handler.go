@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"parish-viva/backend/internal/http/middleware"
+	"parish-viva/backend/internal/http/shared"
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/services"
+)
+
+type PrivacySettingsHandler struct {
+	service *services.Service
+}
+
+type updatePrivacySettingsRequest struct {
+	ProfileVisibility *models.ProfileAudience     `json:"profileVisibility"`
+	StatsVisibility   *models.ProfileAudience     `json:"statsVisibility"`
+	FriendRequests    *models.FriendRequestPolicy `json:"friendRequests"`
+	Searchable        *bool                       `json:"searchable"`
+}
+
+func NewPrivacySettingsHandler(service *services.Service) *PrivacySettingsHandler {
+	return &PrivacySettingsHandler{service: service}
+}
+
+func (h *PrivacySettingsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	settings, err := h.service.GetPrivacySettings(r.Context(), userID)
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, settings)
+}
+
+func (h *PrivacySettingsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	var req updatePrivacySettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	settings, err := h.service.UpdatePrivacySettings(r.Context(), userID, models.UpdatePrivacySettingsInput{
+		ProfileVisibility: req.ProfileVisibility,
+		StatsVisibility:   req.StatsVisibility,
+		FriendRequests:    req.FriendRequests,
+		Searchable:        req.Searchable,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidProfileVisibility) || errors.Is(err, services.ErrInvalidStatsVisibility) ||
+			errors.Is(err, services.ErrInvalidFriendRequestPolicy) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, settings)
+}
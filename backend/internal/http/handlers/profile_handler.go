@@ -30,6 +30,10 @@ type updateTraditionRequest struct {
 	Tradition string `json:"tradition"`
 }
 
+type updateLocaleRequest struct {
+	Locale string `json:"locale"`
+}
+
 func NewProfileHandler(service *services.Service) *ProfileHandler {
 	return &ProfileHandler{service: service}
 }
@@ -129,6 +133,29 @@ func (h *ProfileHandler) UpdateTradition(w http.ResponseWriter, r *http.Request)
 	shared.WriteJSON(w, http.StatusOK, profile)
 }
 
+func (h *ProfileHandler) UpdateLocale(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	var req updateLocaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	profile, err := h.service.SetUserLocale(r.Context(), userID, models.Locale(req.Locale))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidLocale) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid locale", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, profile)
+}
+
 func (h *ProfileHandler) UsernameAvailability(w http.ResponseWriter, r *http.Request) {
 	username := r.URL.Query().Get("username")
 	available, err := h.service.IsUsernameAvailable(r.Context(), username)
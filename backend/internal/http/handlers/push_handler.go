@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"parish-viva/backend/internal/http/middleware"
+	"parish-viva/backend/internal/http/shared"
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+	"parish-viva/backend/internal/services"
+)
+
+type PushHandler struct {
+	service *services.Service
+}
+
+// pushSubscriptionRequest matches PushSubscription.toJSON() in the browser.
+type pushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+type deletePushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+}
+
+func NewPushHandler(service *services.Service) *PushHandler {
+	return &PushHandler{service: service}
+}
+
+// PublicKey returns the VAPID key the web app subscribes with.
+func (h *PushHandler) PublicKey(w http.ResponseWriter, r *http.Request) {
+	key, err := h.service.PushPublicKey()
+	if err != nil {
+		shared.WriteError(w, http.StatusServiceUnavailable, "PUSH_DISABLED", "Push notifications are not available", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"publicKey": key})
+}
+
+func (h *PushHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	var req pushSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	sub, err := h.service.SavePushSubscription(r.Context(), userID, models.PushSubscription{
+		Endpoint: req.Endpoint,
+		P256dh:   req.Keys.P256dh,
+		Auth:     req.Keys.Auth,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrPushDisabled) {
+			shared.WriteError(w, http.StatusServiceUnavailable, "PUSH_DISABLED", "Push notifications are not available", nil)
+			return
+		}
+		if errors.Is(err, services.ErrInvalidPushSubscription) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, map[string]any{"id": sub.ID, "endpoint": sub.Endpoint})
+}
+
+func (h *PushHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	var req deletePushSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	if err := h.service.DeletePushSubscription(r.Context(), userID, req.Endpoint); err != nil {
+		if errors.Is(err, services.ErrInvalidPushSubscription) {
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, repositories.ErrPushSubscriptionNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "PUSH_SUBSCRIPTION_NOT_FOUND", "Push subscription not found", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]any{"status": "deleted"})
+}
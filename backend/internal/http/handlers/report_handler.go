@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"parish-viva/backend/internal/http/middleware"
+	"parish-viva/backend/internal/http/shared"
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+	"parish-viva/backend/internal/services"
+)
+
+type ReportHandler struct {
+	service *services.Service
+}
+
+type createReportRequest struct {
+	Reason  string `json:"reason"`
+	Details string `json:"details"`
+}
+
+func NewReportHandler(service *services.Service) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+func (h *ReportHandler) ReportRequest(w http.ResponseWriter, r *http.Request) {
+	h.report(w, r, func(reporterID string, reason models.ReportReason, details string) (models.Report, bool, error) {
+		return h.service.ReportPrayerRequest(r.Context(), reporterID, chi.URLParam(r, "id"), reason, details)
+	})
+}
+
+func (h *ReportHandler) ReportUser(w http.ResponseWriter, r *http.Request) {
+	h.report(w, r, func(reporterID string, reason models.ReportReason, details string) (models.Report, bool, error) {
+		return h.service.ReportUser(r.Context(), reporterID, chi.URLParam(r, "username"), reason, details)
+	})
+}
+
+func (h *ReportHandler) report(w http.ResponseWriter, r *http.Request, file func(reporterID string, reason models.ReportReason, details string) (models.Report, bool, error)) {
+	userID := middleware.GetString(r.Context(), middleware.ContextKeyUserID)
+	if err := ensureAuthUser(h.service, r); err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "USER_SYNC_FAILED", "Could not prepare user profile", nil)
+		return
+	}
+	var req createReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON payload", nil)
+		return
+	}
+	// Accept "self-harm-risk" and "self_harm_risk" alike.
+	reason := models.ReportReason(strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(req.Reason), "-", "_")))
+	report, created, err := file(userID, reason, req.Details)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidReportReason), errors.Is(err, services.ErrInvalidReportDetails):
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		case errors.Is(err, services.ErrCannotTargetSelf):
+			shared.WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "You cannot report yourself", nil)
+		case errors.Is(err, repositories.ErrPrayerRequestNotFound):
+			shared.WriteError(w, http.StatusNotFound, "REQUEST_NOT_FOUND", "Prayer request not found", nil)
+		case errors.Is(err, repositories.ErrUserNotFound):
+			shared.WriteError(w, http.StatusNotFound, "USER_NOT_FOUND", "User not found", nil)
+		default:
+			shared.WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Unexpected error", nil)
+		}
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	shared.WriteJSON(w, status, report)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// sseWriteTimeout bounds each event write, since streams outlive WriteTimeout.
+const sseWriteTimeout = 10 * time.Second
+
+// sseWriter writes a text/event-stream response.
+type sseWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func newSSEWriter(w http.ResponseWriter) *sseWriter {
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	return &sseWriter{w: w, rc: http.NewResponseController(w)}
+}
+
+// event sends JSON data; a non-empty id becomes the Last-Event-ID.
+func (s *sseWriter) event(name, id string, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	msg := ""
+	if id != "" {
+		msg += "id: " + id + "\n"
+	}
+	msg += "event: " + name + "\ndata: " + string(body) + "\n\n"
+	return s.write(msg)
+}
+
+// comment sends an SSE comment, which clients ignore; used as a heartbeat.
+func (s *sseWriter) comment(text string) error {
+	return s.write(": " + text + "\n\n")
+}
+
+// retry tells the client how long to wait before reconnecting.
+func (s *sseWriter) retry(after time.Duration) error {
+	return s.write(fmt.Sprintf("retry: %d\n\n", after.Milliseconds()))
+}
+
+func (s *sseWriter) write(msg string) error {
+	_ = s.rc.SetWriteDeadline(time.Now().Add(sseWriteTimeout))
+	if _, err := fmt.Fprint(s.w, msg); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
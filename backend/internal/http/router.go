@@ -22,10 +22,16 @@ func NewRouter(cfg config.Config, logger *zap.Logger, service *services.Service)
 	healthHandler := handlers.NewHealthHandler()
 	profileHandler := handlers.NewProfileHandler(service)
 	prayerHandler := handlers.NewPrayerHandler(service, cfg.PrayedWindowHours)
-	moderationHandler := handlers.NewModerationHandler()
+	moderationHandler := handlers.NewModerationHandler(service)
 	groupHandler := handlers.NewGroupHandler(service)
 	friendHandler := handlers.NewFriendHandler(service)
-	notificationHandler := handlers.NewNotificationHandler(service)
+	blockHandler := handlers.NewBlockHandler(service)
+	notificationHandler := handlers.NewNotificationHandler(service, cfg.NotificationStreamHeartbeat)
+	reportHandler := handlers.NewReportHandler(service)
+	commentHandler := handlers.NewCommentHandler(service)
+	notificationPreferencesHandler := handlers.NewNotificationPreferencesHandler(service)
+	privacySettingsHandler := handlers.NewPrivacySettingsHandler(service)
+	pushHandler := handlers.NewPushHandler(service)
 
 	r.Use(chimiddleware.Recoverer)
 	r.Use(middleware.RequestID)
@@ -38,14 +44,26 @@ func NewRouter(cfg config.Config, logger *zap.Logger, service *services.Service)
 	r.Route("/api/v1", func(api chi.Router) {
 		api.With(middleware.OptionalAuth(validator)).Get("/feed", prayerHandler.ListPublic)
 		api.With(middleware.OptionalAuth(validator)).Get("/feed/public", prayerHandler.ListPublic)
+		api.With(middleware.OptionalAuth(validator)).Get("/requests/search", prayerHandler.Search)
 		api.Get("/username-availability", profileHandler.UsernameAvailability)
+		api.Get("/email/unsubscribe/{token}", notificationPreferencesHandler.ConfirmUnsubscribe)
+		api.Post("/email/unsubscribe/{token}", notificationPreferencesHandler.Unsubscribe)
+		api.Get("/push/vapid-public-key", pushHandler.PublicKey)
 
 		api.Group(func(protected chi.Router) {
 			protected.Use(middleware.RequireAuth(validator))
 			protected.Get("/profile", profileHandler.GetProfile)
 			protected.Patch("/profile", profileHandler.UpdateProfile)
 			protected.Patch("/profile/tradition", profileHandler.UpdateTradition)
+			protected.Patch("/profile/locale", profileHandler.UpdateLocale)
+			protected.Get("/profile/notification-preferences", notificationPreferencesHandler.Get)
+			protected.Patch("/profile/notification-preferences", notificationPreferencesHandler.Update)
+			protected.Get("/profile/privacy", privacySettingsHandler.Get)
+			protected.Patch("/profile/privacy", privacySettingsHandler.Update)
+			protected.Post("/push/subscriptions", pushHandler.Subscribe)
+			protected.Delete("/push/subscriptions", pushHandler.Unsubscribe)
 			protected.Get("/users/{username}", profileHandler.GetPublicProfile)
+			protected.Post("/users/{username}/reports", reportHandler.ReportUser)
 			protected.Get("/feed/home", prayerHandler.ListHome)
 			protected.Get("/feed/groups", prayerHandler.ListGroupsFeed)
 			protected.Get("/feed/friends", prayerHandler.ListFriendsFeed)
@@ -58,6 +76,9 @@ func NewRouter(cfg config.Config, logger *zap.Logger, service *services.Service)
 			protected.Get("/groups/{id}/members", groupHandler.ListMembers)
 			protected.Patch("/groups/{id}/members/{userId}", groupHandler.ChangeMemberRole)
 			protected.Delete("/groups/{id}/members/{userId}", groupHandler.RemoveMember)
+			protected.Get("/groups/{id}/bans", groupHandler.ListBans)
+			protected.Post("/groups/{id}/bans", groupHandler.Ban)
+			protected.Delete("/groups/{id}/bans/{userId}", groupHandler.Unban)
 			protected.Post("/groups/{id}/leave", groupHandler.Leave)
 			protected.Post("/groups/{id}/join-requests", groupHandler.RequestJoin)
 			protected.Get("/groups/{id}/join-requests", groupHandler.ListJoinRequests)
@@ -65,21 +86,51 @@ func NewRouter(cfg config.Config, logger *zap.Logger, service *services.Service)
 			protected.Post("/groups/{id}/join-requests/{requestId}/reject", groupHandler.RejectJoinRequest)
 			protected.Get("/friends", friendHandler.ListFriends)
 			protected.Get("/friends/requests", friendHandler.ListPendingRequests)
+			protected.Get("/friends/suggestions", friendHandler.ListSuggestions)
+			protected.Post("/friends/suggestions/{userId}/dismiss", friendHandler.DismissSuggestion)
 			protected.Post("/friends/requests", friendHandler.SendRequest)
+			protected.Get("/friends/requests/outgoing", friendHandler.ListOutgoingRequests)
 			protected.Post("/friends/requests/{requestId}/accept", friendHandler.AcceptRequest)
+			protected.Post("/friends/requests/{requestId}/reject", friendHandler.RejectRequest)
+			protected.Delete("/friends/requests/{requestId}", friendHandler.CancelRequest)
+			protected.Delete("/friends/{userId}", friendHandler.RemoveFriend)
 			protected.Get("/users/search", friendHandler.SearchUsers)
+			protected.Get("/blocks", blockHandler.List)
+			protected.Post("/blocks/{username}", blockHandler.Block)
+			protected.Delete("/blocks/{username}", blockHandler.Unblock)
 
 			protected.Post("/requests", prayerHandler.Create)
 			protected.Get("/requests/{id}", prayerHandler.GetByID)
 			protected.Patch("/requests/{id}", prayerHandler.Update)
 			protected.Delete("/requests/{id}", prayerHandler.Delete)
 			protected.Post("/requests/{id}/pray", prayerHandler.Pray)
+			protected.Get("/requests/{id}/updates", prayerHandler.ListUpdates)
+			protected.Post("/requests/{id}/updates", prayerHandler.CreateUpdate)
+			protected.Post("/requests/{id}/answered", prayerHandler.MarkAnswered)
+			protected.Post("/requests/{id}/close", prayerHandler.Close)
+			protected.Post("/requests/{id}/keep-active", prayerHandler.KeepActive)
+			protected.Get("/requests/{id}/comments", commentHandler.List)
+			protected.Post("/requests/{id}/comments", commentHandler.Create)
+			protected.Get("/requests/{id}/comments/{commentId}/replies", commentHandler.ListReplies)
+			protected.Delete("/requests/{id}/comments/{commentId}", commentHandler.Delete)
+			protected.Post("/requests/{id}/reports", reportHandler.ReportRequest)
+			protected.Post("/requests/{id}/remove", moderationHandler.RemoveRequest)
+			protected.Get("/requests/{id}/moderation-actions", moderationHandler.ListRequestActions)
 			protected.Get("/moderation/queue", moderationHandler.Queue)
+			protected.Post("/moderation/queue/{id}/claim", moderationHandler.Claim)
+			protected.Post("/moderation/queue/{id}/approve", moderationHandler.Approve)
+			protected.Post("/moderation/queue/{id}/reject", moderationHandler.Reject)
+			protected.Post("/moderation/queue/{id}/request-changes", moderationHandler.RequestChanges)
+			protected.Post("/moderation/queue/{id}/dismiss", moderationHandler.Dismiss)
+			protected.Post("/moderation/actions/{id}/revert", moderationHandler.RevertAction)
 
 			protected.Get("/notifications", notificationHandler.List)
 			protected.Get("/notifications/unread-count", notificationHandler.UnreadCount)
+			protected.Get("/notifications/stream", notificationHandler.Stream)
 			protected.Post("/notifications/{id}/read", notificationHandler.MarkRead)
 			protected.Post("/notifications/read-all", notificationHandler.MarkAllRead)
+			protected.Delete("/notifications/read", notificationHandler.DeleteRead)
+			protected.Delete("/notifications/{id}", notificationHandler.Delete)
 		})
 	})
 
@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"parish-viva/backend/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// Archiver archives idle requests; an advisory lock lets one replica work at a time.
+type Archiver struct {
+	service  *services.Service
+	logger   *zap.Logger
+	interval time.Duration
+}
+
+func NewArchiver(service *services.Service, logger *zap.Logger, interval time.Duration) *Archiver {
+	return &Archiver{service: service, logger: logger, interval: interval}
+}
+
+// Run passes at start and then once per interval until ctx ends.
+func (a *Archiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		a.runOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Archiver) runOnce(ctx context.Context) {
+	result, err := a.service.ArchiveStalePrayerRequests(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			a.logger.Error("archival_job_failed", zap.Error(err))
+		}
+		return
+	}
+	if result.Warned > 0 || result.Archived > 0 {
+		a.logger.Info("archival_job_completed", zap.Int("warned", result.Warned), zap.Int("archived", result.Archived))
+	}
+}
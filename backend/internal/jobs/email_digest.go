@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"parish-viva/backend/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// EmailDigests sends digests as they fall due; replicas claim disjoint users.
+type EmailDigests struct {
+	service  *services.Service
+	logger   *zap.Logger
+	interval time.Duration
+}
+
+func NewEmailDigests(service *services.Service, logger *zap.Logger, interval time.Duration) *EmailDigests {
+	return &EmailDigests{service: service, logger: logger, interval: interval}
+}
+
+// Run checks at start and then once per interval until ctx ends.
+func (d *EmailDigests) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		for d.runOnce(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce sends one batch and reports whether more may be due.
+func (d *EmailDigests) runOnce(ctx context.Context) bool {
+	result, err := d.service.SendEmailDigests(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			d.logger.Error("email_digest_failed", zap.Error(err))
+		}
+		return false
+	}
+	if result.Claimed > 0 {
+		d.logger.Info("email_digest_completed", zap.Int("sent", result.Sent), zap.Int("empty", result.Empty), zap.Int("failed", result.Failed))
+	}
+	return result.Claimed > 0 && ctx.Err() == nil
+}
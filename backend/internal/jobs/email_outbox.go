@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"parish-viva/backend/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// EmailOutbox delivers notification emails; every replica may run one.
+type EmailOutbox struct {
+	service  *services.Service
+	logger   *zap.Logger
+	interval time.Duration
+}
+
+func NewEmailOutbox(service *services.Service, logger *zap.Logger, interval time.Duration) *EmailOutbox {
+	return &EmailOutbox{service: service, logger: logger, interval: interval}
+}
+
+// Run drains the outbox every interval until ctx is cancelled.
+func (o *EmailOutbox) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+	for {
+		for o.runOnce(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce sends one batch and reports whether more may be waiting.
+func (o *EmailOutbox) runOnce(ctx context.Context) bool {
+	result, err := o.service.DeliverNotificationEmails(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			o.logger.Error("email_outbox_failed", zap.Error(err))
+		}
+		return false
+	}
+	if result.Claimed > 0 {
+		o.logger.Info("email_outbox_completed", zap.Int("sent", result.Sent), zap.Int("skipped", result.Skipped), zap.Int("retried", result.Retried))
+	}
+	return result.Claimed > 0 && result.Retried < result.Claimed && ctx.Err() == nil
+}
@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"parish-viva/backend/internal/services"
+
+	"go.uber.org/zap"
+)
+
+const (
+	listenerMinBackoff = time.Second
+	listenerMaxBackoff = 30 * time.Second
+)
+
+// NotificationListener keeps the database listener running, with backoff.
+type NotificationListener struct {
+	service *services.Service
+	logger  *zap.Logger
+}
+
+func NewNotificationListener(service *services.Service, logger *zap.Logger) *NotificationListener {
+	return &NotificationListener{service: service, logger: logger}
+}
+
+// Run blocks until ctx is cancelled.
+func (l *NotificationListener) Run(ctx context.Context) {
+	backoff := listenerMinBackoff
+	for {
+		started := time.Now()
+		err := l.service.ListenForNotifications(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > listenerMaxBackoff {
+			backoff = listenerMinBackoff
+		}
+		l.logger.Warn("notification_listener_disconnected", zap.Error(err), zap.Duration("retryIn", backoff))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, listenerMaxBackoff)
+	}
+}
@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"parish-viva/backend/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// NotificationRetention purges old read notifications; every replica may run one.
+type NotificationRetention struct {
+	service  *services.Service
+	logger   *zap.Logger
+	interval time.Duration
+}
+
+func NewNotificationRetention(service *services.Service, logger *zap.Logger, interval time.Duration) *NotificationRetention {
+	return &NotificationRetention{service: service, logger: logger, interval: interval}
+}
+
+// Run passes at start and then once per interval until ctx ends.
+func (n *NotificationRetention) Run(ctx context.Context) {
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+	for {
+		n.runOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (n *NotificationRetention) runOnce(ctx context.Context) {
+	purged, err := n.service.PurgeOldNotifications(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			n.logger.Error("notification_retention_failed", zap.Error(err))
+		}
+		return
+	}
+	if purged > 0 {
+		n.logger.Info("notification_retention_completed", zap.Int64("purged", purged))
+	}
+}
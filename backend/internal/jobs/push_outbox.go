@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"parish-viva/backend/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// PushOutbox delivers Web Push notifications; every replica may run one.
+type PushOutbox struct {
+	service  *services.Service
+	logger   *zap.Logger
+	interval time.Duration
+}
+
+func NewPushOutbox(service *services.Service, logger *zap.Logger, interval time.Duration) *PushOutbox {
+	return &PushOutbox{service: service, logger: logger, interval: interval}
+}
+
+// Run drains the outbox every interval until ctx is cancelled.
+func (o *PushOutbox) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+	for {
+		for o.runOnce(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce sends one batch and reports whether more may be waiting.
+func (o *PushOutbox) runOnce(ctx context.Context) bool {
+	result, err := o.service.DeliverNotificationPushes(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			o.logger.Error("push_outbox_failed", zap.Error(err))
+		}
+		return false
+	}
+	if result.Claimed > 0 {
+		o.logger.Info("push_outbox_completed", zap.Int("sent", result.Sent), zap.Int("skipped", result.Skipped), zap.Int("retried", result.Retried), zap.Int("gone", result.Gone))
+	}
+	return result.Claimed > 0 && result.Retried < result.Claimed && ctx.Err() == nil
+}
@@ -0,0 +1,130 @@
+package mail
+
+import (
+	"strings"
+	"text/template"
+
+	"parish-viva/backend/internal/models"
+)
+
+type digestData struct {
+	RecipientName     string
+	Weekly            bool
+	Notifications     []string
+	MoreNotifications int
+	Requests          []digestRequestData
+	MoreRequests      int
+	Link              string
+	UnsubscribeURL    string
+}
+
+type digestRequestData struct {
+	Title     string
+	GroupName string
+	Link      string
+}
+
+var digestTexts = map[models.Locale]emailText{
+	models.LocalePortuguese: {
+		subject: `Seu resumo {{if .Weekly}}semanal{{else}}diário{{end}} no Creo`,
+		body: `Olá, {{.RecipientName}}!
+{{if .Notifications}}
+Notificações não lidas:{{range .Notifications}}
+- {{.}}{{end}}{{if .MoreNotifications}}
+- e mais {{.MoreNotifications}}{{end}}
+{{end}}{{if .Requests}}
+Novos pedidos nos seus grupos:{{range .Requests}}
+- "{{.Title}}" em {{.GroupName}}
+  {{.Link}}{{end}}{{if .MoreRequests}}
+- e mais {{.MoreRequests}}{{end}}
+{{end}}
+{{.Link}}
+
+--
+Você recebe este resumo {{if .Weekly}}semanal{{else}}diário{{end}} porque o ativou nas suas preferências de notificação do Creo.
+Para não receber mais emails do Creo: {{.UnsubscribeURL}}`,
+	},
+	models.LocaleEnglish: {
+		subject: `Your {{if .Weekly}}weekly{{else}}daily{{end}} Creo digest`,
+		body: `Hi {{.RecipientName}},
+{{if .Notifications}}
+Unread notifications:{{range .Notifications}}
+- {{.}}{{end}}{{if .MoreNotifications}}
+- and {{.MoreNotifications}} more{{end}}
+{{end}}{{if .Requests}}
+New requests in your groups:{{range .Requests}}
+- "{{.Title}}" in {{.GroupName}}
+  {{.Link}}{{end}}{{if .MoreRequests}}
+- and {{.MoreRequests}} more{{end}}
+{{end}}
+{{.Link}}
+
+--
+You are receiving this {{if .Weekly}}weekly{{else}}daily{{end}} digest because you turned it on in your Creo notification preferences.
+To stop receiving emails from Creo: {{.UnsubscribeURL}}`,
+	},
+}
+
+var digestTemplates = mustParseDigestTemplates()
+
+func mustParseDigestTemplates() map[models.Locale]emailTemplate {
+	out := make(map[models.Locale]emailTemplate, len(digestTexts))
+	for locale, text := range digestTexts {
+		name := "digest." + string(locale)
+		out[locale] = emailTemplate{
+			subject: template.Must(template.New(name + ".subject").Parse(text.subject)),
+			body:    template.Must(template.New(name).Parse(text.body)),
+		}
+	}
+	return out
+}
+
+// RenderDigest builds a digest email with a one-click unsubscribe header.
+func RenderDigest(d models.EmailDigest, baseURL, unsubscribeURL string) (Message, error) {
+	locale := supportedLocale(d.Locale)
+	tmpl := digestTemplates[locale]
+	base := strings.TrimRight(baseURL, "/")
+
+	data := digestData{
+		RecipientName:  d.RecipientName,
+		Weekly:         d.Frequency == models.DigestWeekly,
+		Notifications:  make([]string, 0, len(d.Notifications)),
+		Link:           base + "/feed",
+		UnsubscribeURL: unsubscribeURL,
+	}
+	for _, n := range d.Notifications {
+		line, ok, err := renderSummary(n, locale)
+		if err != nil {
+			return Message{}, err
+		}
+		if ok {
+			data.Notifications = append(data.Notifications, line)
+		}
+	}
+	data.MoreNotifications = max(d.UnreadCount-len(data.Notifications), 0)
+	for _, r := range d.Requests {
+		data.Requests = append(data.Requests, digestRequestData{
+			Title:     r.Title,
+			GroupName: r.GroupName,
+			Link:      base + "/requests/" + r.RequestID,
+		})
+	}
+	data.MoreRequests = max(d.RequestCount-len(d.Requests), 0)
+
+	var subject, body strings.Builder
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To:      d.RecipientEmail,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}, nil
+}
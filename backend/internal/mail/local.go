@@ -0,0 +1,58 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	netmail "net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// LogMailer logs messages instead of sending them, for local development.
+type LogMailer struct {
+	logger *zap.Logger
+}
+
+func NewLogMailer(logger *zap.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+func (m *LogMailer) Send(_ context.Context, msg Message) error {
+	if _, err := recipient(msg); err != nil {
+		return err
+	}
+	m.logger.Info("email_logged", zap.String("subject", msg.Subject))
+	m.logger.Debug("email_logged_content", zap.String("to", msg.To), zap.String("body", msg.Body))
+	return nil
+}
+
+// FileMailer writes each message as an .eml file in a directory.
+type FileMailer struct {
+	dir  string
+	from *netmail.Address
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	sender, err := netmail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("mail: invalid sender address: %w", err)
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileMailer{dir: dir, from: sender}, nil
+}
+
+func (m *FileMailer) Send(_ context.Context, msg Message) error {
+	now := time.Now()
+	data, err := encodeMessage(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405"), uuid.NewString())
+	return os.WriteFile(filepath.Join(m.dir, name), data, 0o644)
+}
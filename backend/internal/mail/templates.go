@@ -0,0 +1,349 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"parish-viva/backend/internal/models"
+)
+
+// ErrNoTemplate is returned for notification types without an email.
+var ErrNoTemplate = errors.New("mail: no template for notification type")
+
+type templateData struct {
+	RecipientName string
+	ActorName     string
+	SubjectTitle  string
+	Link          string
+	Payload       map[string]string
+}
+
+type localeStrings struct {
+	layout     string
+	someone    string
+	dateLayout string
+}
+
+// locales holds each language's strings and layouts.
+var locales = map[models.Locale]localeStrings{
+	models.LocalePortuguese: {
+		layout: `Olá, {{.RecipientName}}!
+
+{{template "body" .}}
+
+{{.Link}}
+
+--
+Você recebe este email porque as notificações por email estão ativadas na sua conta do Creo.
+{{define "request"}}seu pedido{{with .SubjectTitle}} "{{.}}"{{end}}{{end}}`,
+		someone:    "Alguém",
+		dateLayout: "02/01/2006",
+	},
+	models.LocaleEnglish: {
+		layout: `Hi {{.RecipientName}},
+
+{{template "body" .}}
+
+{{.Link}}
+
+--
+You are receiving this email because email notifications are turned on for your Creo account.
+{{define "request"}}your request{{with .SubjectTitle}} "{{.}}"{{end}}{{end}}`,
+		someone:    "Someone",
+		dateLayout: "January 2, 2006",
+	},
+}
+
+type emailText struct {
+	subject string
+	body    string
+}
+
+var emailTexts = map[models.NotificationType]map[models.Locale]emailText{
+	models.NotificationTypePrayed: {
+		models.LocalePortuguese: {
+			subject: `{{.ActorName}}{{with $n := .Payload.othersCount}}{{if eq $n "1"}} e mais uma pessoa oraram{{else if ne $n "0"}} e mais {{$n}} pessoas oraram{{else}} orou{{end}}{{else}} orou{{end}} pelo seu pedido`,
+			body:    `{{.ActorName}}{{with $n := .Payload.othersCount}}{{if eq $n "1"}} e mais uma pessoa oraram{{else if ne $n "0"}} e mais {{$n}} pessoas oraram{{else}} orou{{end}}{{else}} orou{{end}} por {{template "request" .}}.`,
+		},
+		models.LocaleEnglish: {
+			subject: `{{.ActorName}}{{with $n := .Payload.othersCount}}{{if eq $n "1"}} and 1 other person prayed{{else if ne $n "0"}} and {{$n}} others prayed{{else}} prayed{{end}}{{else}} prayed{{end}} for your request`,
+			body:    `{{.ActorName}}{{with $n := .Payload.othersCount}}{{if eq $n "1"}} and 1 other person prayed{{else if ne $n "0"}} and {{$n}} others prayed{{else}} prayed{{end}}{{else}} prayed{{end}} for {{template "request" .}}.`,
+		},
+	},
+	models.NotificationTypeFriendRequestReceived: {
+		models.LocalePortuguese: {
+			subject: `Novo pedido de amizade de {{.ActorName}}`,
+			body:    `{{.ActorName}} enviou um pedido de amizade para você.`,
+		},
+		models.LocaleEnglish: {
+			subject: `New friend request from {{.ActorName}}`,
+			body:    `{{.ActorName}} sent you a friend request.`,
+		},
+	},
+	models.NotificationTypeFriendRequestAccepted: {
+		models.LocalePortuguese: {
+			subject: `{{.ActorName}} aceitou seu pedido de amizade`,
+			body:    `{{.ActorName}} aceitou seu pedido de amizade. Agora vocês acompanham os pedidos de oração um do outro.`,
+		},
+		models.LocaleEnglish: {
+			subject: `{{.ActorName}} accepted your friend request`,
+			body:    `{{.ActorName}} accepted your friend request. You now follow each other's prayer requests.`,
+		},
+	},
+	models.NotificationTypeGroupJoinApproved: {
+		models.LocalePortuguese: {
+			subject: `Você entrou no grupo{{with .SubjectTitle}} {{.}}{{end}}`,
+			body:    `Seu pedido para entrar no grupo{{with .SubjectTitle}} "{{.}}"{{end}} foi aprovado.`,
+		},
+		models.LocaleEnglish: {
+			subject: `You joined {{with .SubjectTitle}}{{.}}{{else}}a group{{end}}`,
+			body:    `Your request to join {{with .SubjectTitle}}"{{.}}"{{else}}the group{{end}} was approved.`,
+		},
+	},
+	models.NotificationTypeGroupJoinRequested: {
+		models.LocalePortuguese: {
+			subject: `Novo pedido para entrar no grupo{{with .SubjectTitle}} {{.}}{{end}}`,
+			body:    `{{.ActorName}} pediu para entrar no grupo{{with .SubjectTitle}} "{{.}}"{{end}}. Você pode aprovar ou recusar o pedido na página do grupo.`,
+		},
+		models.LocaleEnglish: {
+			subject: `New request to join {{with .SubjectTitle}}{{.}}{{else}}your group{{end}}`,
+			body:    `{{.ActorName}} asked to join {{with .SubjectTitle}}"{{.}}"{{else}}your group{{end}}. You can approve or decline the request on the group page.`,
+		},
+	},
+	models.NotificationTypeRequestModerated: {
+		models.LocalePortuguese: {
+			subject: `Seu pedido de oração foi revisado`,
+			body: `{{if eq .Payload.action "APPROVE"}}A moderação aprovou {{template "request" .}}, que já está visível.` +
+				`{{else if eq .Payload.action "REJECT"}}A moderação não aprovou {{template "request" .}}.` +
+				`{{else if eq .Payload.action "REQUEST_CHANGES"}}A moderação pediu alterações em {{template "request" .}} antes de publicá-lo.` +
+				`{{else if eq .Payload.action "REMOVE"}}A moderação removeu {{template "request" .}}.` +
+				`{{else if eq .Payload.action "RESTORE"}}A moderação restaurou {{template "request" .}}.` +
+				`{{else}}A moderação revisou {{template "request" .}}.{{end}}` +
+				`{{with .Payload.reason}}
+
+Motivo: {{.}}{{end}}{{with .Payload.note}}
+
+Observação: {{.}}{{end}}`,
+		},
+		models.LocaleEnglish: {
+			subject: `Your prayer request was reviewed`,
+			body: `{{if eq .Payload.action "APPROVE"}}Moderators approved {{template "request" .}}; it is now visible.` +
+				`{{else if eq .Payload.action "REJECT"}}Moderators did not approve {{template "request" .}}.` +
+				`{{else if eq .Payload.action "REQUEST_CHANGES"}}Moderators asked for changes to {{template "request" .}} before publishing it.` +
+				`{{else if eq .Payload.action "REMOVE"}}Moderators removed {{template "request" .}}.` +
+				`{{else if eq .Payload.action "RESTORE"}}Moderators restored {{template "request" .}}.` +
+				`{{else}}Moderators reviewed {{template "request" .}}.{{end}}` +
+				`{{with .Payload.reason}}
+
+Reason: {{.}}{{end}}{{with .Payload.note}}
+
+Note: {{.}}{{end}}`,
+		},
+	},
+	models.NotificationTypeReportResolved: {
+		models.LocalePortuguese: {
+			subject: `Sua denúncia foi analisada`,
+			body: `A moderação analisou sua denúncia` +
+				`{{if eq .Payload.outcome "DISMISS" "APPROVE"}} e concluiu que o conteúdo não viola as regras da comunidade.` +
+				`{{else}} e tomou as medidas necessárias.{{end}} Obrigado por ajudar a manter o Creo seguro.`,
+		},
+		models.LocaleEnglish: {
+			subject: `Your report was reviewed`,
+			body: `Moderators reviewed your report` +
+				`{{if eq .Payload.outcome "DISMISS" "APPROVE"}} and found that the content does not break the community rules.` +
+				`{{else}} and took action.{{end}} Thank you for helping keep Creo safe.`,
+		},
+	},
+	models.NotificationTypeRequestUpdated: {
+		models.LocalePortuguese: {
+			subject: `Novidades em um pedido pelo qual você orou`,
+			body:    `{{.ActorName}} publicou uma atualização no pedido{{with .SubjectTitle}} "{{.}}"{{end}} pelo qual você orou.`,
+		},
+		models.LocaleEnglish: {
+			subject: `News on a request you prayed for`,
+			body:    `{{.ActorName}} posted an update on the request{{with .SubjectTitle}} "{{.}}"{{end}} you prayed for.`,
+		},
+	},
+	models.NotificationTypeRequestAnswered: {
+		models.LocalePortuguese: {
+			subject: `Uma oração foi atendida`,
+			body:    `O pedido{{with .SubjectTitle}} "{{.}}"{{end}}, pelo qual você orou, foi marcado como atendido. Veja o testemunho no Creo.`,
+		},
+		models.LocaleEnglish: {
+			subject: `A prayer was answered`,
+			body:    `The request{{with .SubjectTitle}} "{{.}}"{{end}} you prayed for was marked as answered. Read the testimony on Creo.`,
+		},
+	},
+	models.NotificationTypeRequestClosed: {
+		models.LocalePortuguese: {
+			subject: `Um pedido pelo qual você orou foi encerrado`,
+			body:    `O pedido{{with .SubjectTitle}} "{{.}}"{{end}}, pelo qual você orou, foi encerrado pelo autor. Obrigado pelas suas orações.`,
+		},
+		models.LocaleEnglish: {
+			subject: `A request you prayed for was closed`,
+			body:    `The request{{with .SubjectTitle}} "{{.}}"{{end}} you prayed for was closed by its author. Thank you for your prayers.`,
+		},
+	},
+	models.NotificationTypeRequestArchiveWarning: {
+		models.LocalePortuguese: {
+			subject: `Seu pedido de oração será arquivado em breve`,
+			body:    `Como não houve atividade recente, {{template "request" .}} será arquivado{{with .Payload.archivesAt}} em {{date .}}{{end}}. Para mantê-lo ativo, abra o pedido e escolha "Manter ativo".`,
+		},
+		models.LocaleEnglish: {
+			subject: `Your prayer request will be archived soon`,
+			body:    `Since there has been no recent activity, {{template "request" .}} will be archived{{with .Payload.archivesAt}} on {{date .}}{{end}}. To keep it active, open the request and choose "Keep active".`,
+		},
+	},
+	models.NotificationTypeRequestArchived: {
+		models.LocalePortuguese: {
+			subject: `Seu pedido de oração foi arquivado`,
+			body:    `Como não houve atividade recente, {{template "request" .}} foi arquivado. Você pode reativá-lo quando quiser.`,
+		},
+		models.LocaleEnglish: {
+			subject: `Your prayer request was archived`,
+			body:    `Since there has been no recent activity, {{template "request" .}} was archived. You can reactivate it at any time.`,
+		},
+	},
+	models.NotificationTypeCommentReceived: {
+		models.LocalePortuguese: {
+			subject: `{{.ActorName}} comentou em um pedido de oração`,
+			body:    `{{.ActorName}} {{if .Payload.parentId}}respondeu ao seu comentário no pedido{{else}}comentou no pedido{{end}}{{with .SubjectTitle}} "{{.}}"{{end}}.`,
+		},
+		models.LocaleEnglish: {
+			subject: `{{.ActorName}} commented on a prayer request`,
+			body:    `{{.ActorName}} {{if .Payload.parentId}}replied to your comment on the request{{else}}commented on the request{{end}}{{with .SubjectTitle}} "{{.}}"{{end}}.`,
+		},
+	},
+}
+
+type emailTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+var emailTemplates = mustParseTemplates()
+
+func mustParseTemplates() map[models.NotificationType]map[models.Locale]emailTemplate {
+	out := make(map[models.NotificationType]map[models.Locale]emailTemplate, len(emailTexts))
+	for notificationType, texts := range emailTexts {
+		out[notificationType] = make(map[models.Locale]emailTemplate, len(texts))
+		for locale, text := range texts {
+			strs := locales[locale]
+			funcs := template.FuncMap{"date": formatDate(strs.dateLayout)}
+			name := string(notificationType) + "." + string(locale)
+			body := template.Must(template.New(name).Funcs(funcs).Option("missingkey=zero").Parse(strs.layout))
+			template.Must(body.New("body").Parse(text.body))
+			subject := template.Must(template.New(name + ".subject").Option("missingkey=zero").Parse(text.subject))
+			out[notificationType][locale] = emailTemplate{subject: subject, body: body}
+		}
+	}
+	return out
+}
+
+// formatDate renders an RFC 3339 payload timestamp as a calendar date.
+func formatDate(layout string) func(string) string {
+	return func(value string) string {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return value
+		}
+		return t.Format(layout)
+	}
+}
+
+// Render builds a notification email, falling back to Portuguese.
+func Render(n models.NotificationEmail, baseURL string) (Message, error) {
+	tmpl, locale, ok := lookupTemplate(n.Type, n.Locale)
+	if !ok {
+		return Message{}, ErrNoTemplate
+	}
+	data := newTemplateData(n, locale, baseURL)
+
+	var subject, body strings.Builder
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To:      n.RecipientEmail,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
+
+func renderSummary(n models.NotificationEmail, locale models.Locale) (string, bool, error) {
+	tmpl, locale, ok := lookupTemplate(n.Type, locale)
+	if !ok {
+		return "", false, nil
+	}
+	var subject strings.Builder
+	if err := tmpl.subject.Execute(&subject, newTemplateData(n, locale, "")); err != nil {
+		return "", false, err
+	}
+	return strings.TrimSpace(subject.String()), true, nil
+}
+
+// Summary renders a notification's email subject line, for push headlines.
+func Summary(n models.NotificationEmail) (string, bool, error) {
+	return renderSummary(n, n.Locale)
+}
+
+// Link returns where a notification leads in the web app at baseURL.
+func Link(n models.NotificationEmail, baseURL string) string {
+	return notificationLink(n, baseURL)
+}
+
+func lookupTemplate(notificationType models.NotificationType, locale models.Locale) (emailTemplate, models.Locale, bool) {
+	byLocale, ok := emailTemplates[notificationType]
+	if !ok {
+		return emailTemplate{}, "", false
+	}
+	locale = supportedLocale(locale)
+	return byLocale[locale], locale, true
+}
+
+// supportedLocale falls back to Portuguese for locales without templates.
+func supportedLocale(locale models.Locale) models.Locale {
+	if _, ok := locales[locale]; ok {
+		return locale
+	}
+	return models.LocalePortuguese
+}
+
+func newTemplateData(n models.NotificationEmail, locale models.Locale, baseURL string) templateData {
+	data := templateData{
+		RecipientName: n.RecipientName,
+		ActorName:     n.ActorName,
+		SubjectTitle:  n.SubjectTitle,
+		Link:          notificationLink(n, baseURL),
+		Payload:       make(map[string]string, len(n.Payload)),
+	}
+	if data.ActorName == "" {
+		data.ActorName = locales[locale].someone
+	}
+	for key, value := range n.Payload {
+		if value != nil {
+			data.Payload[key] = fmt.Sprint(value)
+		}
+	}
+	return data
+}
+
+func notificationLink(n models.NotificationEmail, baseURL string) string {
+	base := strings.TrimRight(baseURL, "/")
+	switch n.SubjectType {
+	case models.NotificationSubjectPrayerRequest:
+		return base + "/requests/" + n.SubjectID
+	case models.NotificationSubjectGroup:
+		return base + "/groups/" + n.SubjectID
+	case models.NotificationSubjectFriendship:
+		return base + "/friends"
+	default:
+		return base + "/feed"
+	}
+}
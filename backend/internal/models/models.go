@@ -59,6 +59,14 @@ const (
 	TraditionEvangelical Tradition = "EVANGELICAL"
 )
 
+// Locale is the language a user reads emails in.
+type Locale string
+
+const (
+	LocalePortuguese Locale = "pt"
+	LocaleEnglish    Locale = "en"
+)
+
 const (
 	JoinPolicyOpen       GroupJoinPolicy = "OPEN"
 	JoinPolicyRequest    GroupJoinPolicy = "REQUEST"
@@ -77,6 +85,9 @@ const (
 	ActionRequestChanges ModerationActionType = "REQUEST_CHANGES"
 	ActionRemove         ModerationActionType = "REMOVE"
 	ActionBan            ModerationActionType = "BAN"
+	ActionRestore        ModerationActionType = "RESTORE"
+	ActionUnban          ModerationActionType = "UNBAN"
+	ActionDismiss        ModerationActionType = "DISMISS"
 )
 
 type User struct {
@@ -87,6 +98,7 @@ type User struct {
 	AvatarURL   *string   `json:"avatarUrl,omitempty"`
 	Bio         *string   `json:"bio,omitempty"`
 	Tradition   Tradition `json:"tradition"`
+	Locale      Locale    `json:"locale"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
@@ -108,33 +120,196 @@ type ProfileStats struct {
 	PrayerActionsByCategory map[string]int `json:"prayerActionsByCategory"`
 }
 
+// PublicProfile is a profile as the viewer may see it; Restricted hides all but the name.
 type PublicProfile struct {
-	User                  User                  `json:"user"`
-	FriendshipStatus      PublicFriendshipState `json:"friendshipStatus"`
-	IncomingFriendReqID   *string               `json:"incomingFriendRequestId,omitempty"`
-	Stats                 *ProfileStats         `json:"stats,omitempty"`
+	User                 User                  `json:"user"`
+	FriendshipStatus     PublicFriendshipState `json:"friendshipStatus"`
+	IncomingFriendReqID  *string               `json:"incomingFriendRequestId,omitempty"`
+	Stats                *ProfileStats         `json:"stats,omitempty"`
+	Restricted           bool                  `json:"restricted"`
+	CanSendFriendRequest bool                  `json:"canSendFriendRequest"`
+	*ProfileConnection
+}
+
+// ProfileConnection is what a viewer has in common with a profile's owner.
+type ProfileConnection struct {
+	MutualFriendCount int64         `json:"mutualFriendCount"`
+	MutualFriends     []UserSummary `json:"mutualFriends"`
+	SharedGroups      []SharedGroup `json:"sharedGroups"`
+	LastPrayedAt      *time.Time    `json:"lastPrayedAt,omitempty"`
+}
+
+type SharedGroup struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	ImageURL *string `json:"imageUrl,omitempty"`
 }
 
 type PrayerRequest struct {
-	ID                string           `json:"id"`
-	AuthorID          string           `json:"authorId"`
-	AuthorUsername    string           `json:"authorUsername,omitempty"`
-	AuthorDisplayName string           `json:"authorDisplayName,omitempty"`
-	AuthorAvatarURL   *string          `json:"authorAvatarUrl,omitempty"`
-	Title             string           `json:"title"`
-	Body              string           `json:"body"`
-	Category          PrayerCategory   `json:"category"`
-	Visibility        Visibility       `json:"visibility"`
-	Tradition         Tradition        `json:"tradition"`
-	AllowAnonymous    bool             `json:"allowAnonymous"`
-	Status            PrayerStatus     `json:"status"`
-	PrayedCount       int64            `json:"prayedCount"`
-	GroupIDs          []string         `json:"groupIds,omitempty"`
-	GroupNames        []string         `json:"groupNames,omitempty"`
-	PrayerTypeCounts  map[string]int64 `json:"prayerTypeCounts,omitempty"`
-	MyPrayerTypes     []string         `json:"myPrayerTypes,omitempty"`
-	CreatedAt         time.Time        `json:"createdAt"`
-	UpdatedAt         time.Time        `json:"updatedAt"`
+	ID                string            `json:"id"`
+	AuthorID          string            `json:"authorId"`
+	AuthorUsername    string            `json:"authorUsername,omitempty"`
+	AuthorDisplayName string            `json:"authorDisplayName,omitempty"`
+	AuthorAvatarURL   *string           `json:"authorAvatarUrl,omitempty"`
+	Title             string            `json:"title"`
+	Body              string            `json:"body"`
+	Category          PrayerCategory    `json:"category"`
+	Visibility        Visibility        `json:"visibility"`
+	Tradition         Tradition         `json:"tradition"`
+	AllowAnonymous    bool              `json:"allowAnonymous"`
+	Status            PrayerStatus      `json:"status"`
+	PrayedCount       int64             `json:"prayedCount"`
+	GroupIDs          []string          `json:"groupIds,omitempty"`
+	GroupNames        []string          `json:"groupNames,omitempty"`
+	PrayerTypeCounts  map[string]int64  `json:"prayerTypeCounts,omitempty"`
+	MyPrayerTypes     []string          `json:"myPrayerTypes,omitempty"`
+	Review            *PrayerReview     `json:"review,omitempty"`
+	LatestUpdate      *PrayerUpdate     `json:"latestUpdate,omitempty"`
+	Resolution        *PrayerResolution `json:"resolution,omitempty"`
+	Testimony         *string           `json:"testimony,omitempty"`
+	ClosedAt          *time.Time        `json:"closedAt,omitempty"`
+	CommentsEnabled   bool              `json:"commentsEnabled"`
+	CommentCount      int64             `json:"commentCount"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	UpdatedAt         time.Time         `json:"updatedAt"`
+}
+
+// PrayerResolution records how a CLOSED request ended.
+type PrayerResolution string
+
+const (
+	ResolutionAnswered PrayerResolution = "ANSWERED"
+	ResolutionClosed   PrayerResolution = "CLOSED"
+)
+
+type ClosePrayerRequestInput struct {
+	RequestID  string
+	AuthorID   string
+	Resolution PrayerResolution
+	Testimony  string
+}
+
+// FeedFilter narrows feeds and search; the zero value lists ACTIVE requests.
+type FeedFilter struct {
+	AnsweredOnly   bool
+	Statuses       []PrayerStatus
+	Categories     []PrayerCategory
+	GroupIDs       []string
+	AuthorUsername string
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+	HasUpdates     *bool
+}
+
+// FeedCursor is a keyset position; feeds order by createdAt, id descending.
+type FeedCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// FeedPage's After, when set, takes precedence over Offset.
+type FeedPage struct {
+	Limit  int
+	Offset int
+	After  *FeedCursor
+}
+
+// FeedRelation is how the viewer relates to a home feed request.
+type FeedRelation string
+
+const (
+	FeedRelationOwn    FeedRelation = "OWN"
+	FeedRelationFriend FeedRelation = "FRIEND"
+	FeedRelationGroup  FeedRelation = "GROUP"
+)
+
+// FeedCandidate is what the ranked home feed scores a request by.
+type FeedCandidate struct {
+	RequestID    string
+	CreatedAt    time.Time
+	PrayedCount  int64
+	Relation     FeedRelation
+	ViewerPrayed bool
+}
+
+type SearchPrayerRequestsInput struct {
+	ViewerID string
+	Query    string
+	Filter   FeedFilter
+	Limit    int
+	Offset   int
+}
+
+// PrayerRequestSearchHit highlights are escaped HTML with <mark> tags.
+type PrayerRequestSearchHit struct {
+	PrayerRequest
+	Rank           float32 `json:"rank"`
+	TitleHighlight string  `json:"titleHighlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+type CommentStatus string
+
+const (
+	CommentStatusPendingReview CommentStatus = "PENDING_REVIEW"
+	CommentStatusVisible       CommentStatus = "VISIBLE"
+	CommentStatusRemoved       CommentStatus = "REMOVED"
+)
+
+// Comment is a reply on a prayer request; replies cannot be replied to.
+type Comment struct {
+	ID                string        `json:"id"`
+	PrayerRequestID   string        `json:"prayerRequestId"`
+	ParentID          *string       `json:"parentId,omitempty"`
+	AuthorID          string        `json:"authorId"`
+	AuthorUsername    string        `json:"authorUsername,omitempty"`
+	AuthorDisplayName string        `json:"authorDisplayName,omitempty"`
+	AuthorAvatarURL   *string       `json:"authorAvatarUrl,omitempty"`
+	Body              string        `json:"body"`
+	Status            CommentStatus `json:"status"`
+	Replies           []Comment     `json:"replies,omitempty"`
+	// HasMoreReplies is set when a list shows only the first replies.
+	HasMoreReplies bool      `json:"hasMoreReplies,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type CreateCommentInput struct {
+	RequestID string
+	AuthorID  string
+	ParentID  string
+	Body      string
+	// ReviewGroupIDs is set by the service.
+	ReviewGroupIDs []string
+}
+
+// ArchivalPolicy archives requests idle for InactiveFor, warning WarnBefore ahead.
+type ArchivalPolicy struct {
+	InactiveFor time.Duration
+	WarnBefore  time.Duration
+}
+
+type ArchivalResult struct {
+	Warned   int
+	Archived int
+}
+
+// PrayerUpdate is a follow-up the author posts on their own request.
+type PrayerUpdate struct {
+	ID              string    `json:"id"`
+	PrayerRequestID string    `json:"prayerRequestId"`
+	AuthorID        string    `json:"authorId"`
+	Body            string    `json:"body"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// PrayerReview tells the author why their request is PENDING_REVIEW.
+type PrayerReview struct {
+	PendingGroupNames []string `json:"pendingGroupNames"`
+	PlatformReview    bool     `json:"platformReview"`
+	ChangesRequested  bool     `json:"changesRequested"`
+	Reason            *string  `json:"reason,omitempty"`
+	Note              *string  `json:"note,omitempty"`
 }
 
 type CreatePrayerRequestInput struct {
@@ -145,6 +320,11 @@ type CreatePrayerRequestInput struct {
 	Visibility     Visibility
 	AllowAnonymous bool
 	GroupIDs       []string
+	// CommentsEnabled defaults to true when nil.
+	CommentsEnabled *bool
+	// ReviewGroupIDs and ReviewPublic are set by the service.
+	ReviewGroupIDs []string
+	ReviewPublic   bool
 }
 
 type UpdatePrayerRequestInput struct {
@@ -156,6 +336,10 @@ type UpdatePrayerRequestInput struct {
 	Visibility     Visibility
 	AllowAnonymous bool
 	GroupIDs       []string
+	// CommentsEnabled leaves the current setting untouched when nil.
+	CommentsEnabled *bool
+	ReviewGroupIDs  []string
+	ReviewPublic    bool
 }
 
 type Group struct {
@@ -195,11 +379,34 @@ type GroupMember struct {
 	JoinedAt    time.Time `json:"joinedAt"`
 }
 
+// GroupBan with a nil ExpiresAt is permanent.
+type GroupBan struct {
+	ID          string     `json:"id"`
+	GroupID     string     `json:"groupId"`
+	UserID      string     `json:"userId"`
+	Username    string     `json:"username"`
+	DisplayName string     `json:"displayName"`
+	AvatarURL   *string    `json:"avatarUrl,omitempty"`
+	ActorUserID string     `json:"actorUserId"`
+	Reason      string     `json:"reason"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type CreateGroupBanInput struct {
+	GroupID     string
+	UserID      string
+	ActorUserID string
+	Reason      string
+	ExpiresAt   *time.Time
+}
+
 type UpdateGroupInput struct {
-	Name        *string
-	Description *string
-	ImageURL    *string
-	JoinPolicy  *GroupJoinPolicy
+	Name               *string
+	Description        *string
+	ImageURL           *string
+	JoinPolicy         *GroupJoinPolicy
+	RequiresModeration *bool
 }
 
 func RoleRank(role GroupRole) int {
@@ -251,6 +458,35 @@ type FriendRequest struct {
 	RequestedAt time.Time `json:"requestedAt"`
 }
 
+// OutgoingFriendRequest is a request the viewer sent that is still pending.
+type OutgoingFriendRequest struct {
+	ID          string    `json:"id"`
+	ToUserID    string    `json:"toUserId"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"displayName"`
+	AvatarURL   *string   `json:"avatarUrl,omitempty"`
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+type FriendSuggestion struct {
+	UserID        string  `json:"userId"`
+	Username      string  `json:"username"`
+	DisplayName   string  `json:"displayName"`
+	AvatarURL     *string `json:"avatarUrl,omitempty"`
+	MutualFriends int64   `json:"mutualFriends"`
+	SharedGroups  int64   `json:"sharedGroups"`
+	SameTradition bool    `json:"sameTradition"`
+	RecentPrayers int64   `json:"recentPrayers"`
+}
+
+type BlockedUser struct {
+	UserID      string    `json:"userId"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"displayName"`
+	AvatarURL   *string   `json:"avatarUrl,omitempty"`
+	BlockedAt   time.Time `json:"blockedAt"`
+}
+
 type UserSummary struct {
 	UserID      string  `json:"userId"`
 	Username    string  `json:"username"`
@@ -266,14 +502,40 @@ const (
 	NotificationTypeFriendRequestAccepted NotificationType = "FRIEND_REQUEST_ACCEPTED"
 	NotificationTypeGroupJoinApproved     NotificationType = "GROUP_JOIN_APPROVED"
 	NotificationTypeGroupJoinRequested    NotificationType = "GROUP_JOIN_REQUESTED"
+	NotificationTypeRequestModerated      NotificationType = "REQUEST_MODERATED"
+	NotificationTypeReportResolved        NotificationType = "REPORT_RESOLVED"
+	NotificationTypeRequestUpdated        NotificationType = "REQUEST_UPDATED"
+	NotificationTypeRequestAnswered       NotificationType = "REQUEST_ANSWERED"
+	NotificationTypeRequestClosed         NotificationType = "REQUEST_CLOSED"
+	NotificationTypeRequestArchiveWarning NotificationType = "REQUEST_ARCHIVE_WARNING"
+	NotificationTypeRequestArchived       NotificationType = "REQUEST_ARCHIVED"
+	NotificationTypeCommentReceived       NotificationType = "COMMENT_RECEIVED"
 )
 
+// NotificationTypes lists every notification type users can set channels for.
+var NotificationTypes = []NotificationType{
+	NotificationTypePrayed,
+	NotificationTypeFriendRequestReceived,
+	NotificationTypeFriendRequestAccepted,
+	NotificationTypeGroupJoinApproved,
+	NotificationTypeGroupJoinRequested,
+	NotificationTypeRequestModerated,
+	NotificationTypeReportResolved,
+	NotificationTypeRequestUpdated,
+	NotificationTypeRequestAnswered,
+	NotificationTypeRequestClosed,
+	NotificationTypeRequestArchiveWarning,
+	NotificationTypeRequestArchived,
+	NotificationTypeCommentReceived,
+}
+
 type NotificationSubjectType string
 
 const (
 	NotificationSubjectPrayerRequest NotificationSubjectType = "PRAYER_REQUEST"
 	NotificationSubjectFriendship    NotificationSubjectType = "FRIENDSHIP"
 	NotificationSubjectGroup         NotificationSubjectType = "GROUP"
+	NotificationSubjectUser          NotificationSubjectType = "USER"
 )
 
 type NotificationActor struct {
@@ -283,17 +545,166 @@ type NotificationActor struct {
 	AvatarURL   *string `json:"avatarUrl,omitempty"`
 }
 
+// NotificationView is a listed notification; Actors samples a grouped one's actors.
 type NotificationView struct {
 	ID          string                  `json:"id"`
 	Type        NotificationType        `json:"type"`
 	SubjectType NotificationSubjectType `json:"subjectType"`
 	SubjectID   string                  `json:"subjectId"`
 	Actor       *NotificationActor      `json:"actor,omitempty"`
+	Actors      []NotificationActor     `json:"actors,omitempty"`
 	Payload     map[string]any          `json:"payload"`
 	ReadAt      *time.Time              `json:"readAt,omitempty"`
 	CreatedAt   time.Time               `json:"createdAt"`
 }
 
+// NotificationFilter narrows a notification list. No Types means every type.
+type NotificationFilter struct {
+	Types      []NotificationType
+	UnreadOnly bool
+}
+
+// UnreadNotificationCounts lists every type so clients can badge each tab.
+type UnreadNotificationCounts struct {
+	Count  int64                      `json:"count"`
+	ByType map[NotificationType]int64 `json:"byType"`
+}
+
+// NotificationSignal has no NotificationID when only the count moved.
+type NotificationSignal struct {
+	UserID         string `json:"userId"`
+	NotificationID string `json:"notificationId,omitempty"`
+}
+
+type NotificationEmail struct {
+	NotificationID string
+	Type           NotificationType
+	SubjectType    NotificationSubjectType
+	SubjectID      string
+	// SubjectTitle is the request title or group name, when the subject has one.
+	SubjectTitle   string
+	Payload        map[string]any
+	ActorName      string
+	Attempts       int
+	RecipientEmail string
+	RecipientName  string
+	Locale         Locale
+	EmailEnabled   bool
+	// Digest on means single emails are not sent.
+	Digest    DigestFrequency
+	CreatedAt time.Time
+}
+
+type EmailOutboxResult struct {
+	Claimed int
+	Sent    int
+	Skipped int
+	Retried int
+}
+
+type PushSubscription struct {
+	ID       string
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+// NotificationPush is a claimed push notification and its subscriptions.
+type NotificationPush struct {
+	NotificationEmail
+	Subscriptions []PushSubscription
+}
+
+type PushOutboxResult struct {
+	Claimed int
+	Sent    int
+	Skipped int
+	Retried int
+	// Gone counts subscriptions deleted as unknown to the push service.
+	Gone int
+}
+
+type DigestFrequency string
+
+const (
+	DigestOff    DigestFrequency = "OFF"
+	DigestDaily  DigestFrequency = "DAILY"
+	DigestWeekly DigestFrequency = "WEEKLY"
+)
+
+// DefaultTimezone schedules digests for users who never set a timezone.
+const DefaultTimezone = "America/Sao_Paulo"
+
+// NotificationChannels with every channel off turns the type off.
+type NotificationChannels struct {
+	InApp bool `json:"inApp"`
+	Email bool `json:"email"`
+	Push  bool `json:"push"`
+}
+
+// DefaultNotificationChannels applies to types a user never configured.
+var DefaultNotificationChannels = NotificationChannels{InApp: true, Email: true, Push: true}
+
+// QuietHours are "HH:MM" in the user's timezone and may cross midnight.
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type NotificationPreferences struct {
+	EmailEnabled  bool                                      `json:"emailEnabled"`
+	EmailDigest   DigestFrequency                           `json:"emailDigest"`
+	Timezone      string                                    `json:"timezone"`
+	Types         map[NotificationType]NotificationChannels `json:"types"`
+	QuietHours    *QuietHours                               `json:"quietHours"`
+	MutedGroupIDs []string                                  `json:"mutedGroupIds"`
+}
+
+// UpdateNotificationPreferencesInput changes the fields that are set.
+type UpdateNotificationPreferencesInput struct {
+	EmailEnabled  *bool
+	EmailDigest   *DigestFrequency
+	Timezone      *string
+	Types         map[NotificationType]NotificationChannels
+	QuietHours    *QuietHours
+	SetQuietHours bool
+	MutedGroupIDs *[]string
+}
+
+// DigestRecipient is a user whose digest is due.
+type DigestRecipient struct {
+	UserID           string
+	Frequency        DigestFrequency
+	UnsubscribeToken string
+	PreviousSentAt   *time.Time
+}
+
+// DigestRequest is a new request in one of the recipient's groups.
+type DigestRequest struct {
+	RequestID string
+	Title     string
+	GroupName string
+}
+
+// EmailDigest lists the newest items; the counts cover the whole period.
+type EmailDigest struct {
+	RecipientEmail string
+	RecipientName  string
+	Locale         Locale
+	Frequency      DigestFrequency
+	Notifications  []NotificationEmail
+	UnreadCount    int
+	Requests       []DigestRequest
+	RequestCount   int
+}
+
+type EmailDigestResult struct {
+	Claimed int
+	Sent    int
+	Empty   int
+	Failed  int
+}
+
 type CreateNotificationInput struct {
 	UserID      string
 	Type        NotificationType
@@ -302,3 +713,201 @@ type CreateNotificationInput struct {
 	SubjectID   string
 	Payload     map[string]any
 }
+
+type PlatformRole string
+
+const (
+	PlatformRoleUser      PlatformRole = "USER"
+	PlatformRoleModerator PlatformRole = "MODERATOR"
+	PlatformRoleAdmin     PlatformRole = "ADMIN"
+)
+
+func IsPlatformModerator(role PlatformRole) bool {
+	return role == PlatformRoleModerator || role == PlatformRoleAdmin
+}
+
+type ModerationQueueStatus string
+
+const (
+	QueueStatusPending  ModerationQueueStatus = "PENDING"
+	QueueStatusInReview ModerationQueueStatus = "IN_REVIEW"
+	QueueStatusResolved ModerationQueueStatus = "RESOLVED"
+)
+
+type ModerationReason string
+
+const (
+	ReasonGroupModeration  ModerationReason = "GROUP_MODERATION"
+	ReasonPublicModeration ModerationReason = "PUBLIC_MODERATION"
+	ReasonEdited           ModerationReason = "EDITED"
+	ReasonReverted         ModerationReason = "REVERTED"
+	ReasonReported         ModerationReason = "REPORTED"
+)
+
+// ModerationQueueItem targets a prayer request or, for reports, a user.
+type ModerationQueueItem struct {
+	ID              string                `json:"id"`
+	PrayerRequestID *string               `json:"prayerRequestId,omitempty"`
+	CommentID       *string               `json:"commentId,omitempty"`
+	TargetUserID    *string               `json:"targetUserId,omitempty"`
+	GroupID         *string               `json:"groupId,omitempty"`
+	GroupName       *string               `json:"groupName,omitempty"`
+	Reason          ModerationReason      `json:"reason"`
+	Status          ModerationQueueStatus `json:"status"`
+	ReportCount     int                   `json:"reportCount"`
+	ClaimedBy       *string               `json:"claimedBy,omitempty"`
+	ClaimedAt       *time.Time            `json:"claimedAt,omitempty"`
+	Request         *PrayerRequest        `json:"request,omitempty"`
+	Comment         *Comment              `json:"comment,omitempty"`
+	TargetUser      *ModerationTargetUser `json:"targetUser,omitempty"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+	ResolvedAt      *time.Time            `json:"resolvedAt,omitempty"`
+}
+
+type ModerationTargetUser struct {
+	UserID      string  `json:"userId"`
+	Username    string  `json:"username"`
+	DisplayName string  `json:"displayName"`
+	AvatarURL   *string `json:"avatarUrl,omitempty"`
+}
+
+type ReportReason string
+
+const (
+	ReportReasonSpam         ReportReason = "SPAM"
+	ReportReasonHarassment   ReportReason = "HARASSMENT"
+	ReportReasonSelfHarmRisk ReportReason = "SELF_HARM_RISK"
+	ReportReasonPersonalData ReportReason = "PERSONAL_DATA"
+	ReportReasonOther        ReportReason = "OTHER"
+)
+
+type ReportStatus string
+
+const (
+	ReportStatusOpen     ReportStatus = "OPEN"
+	ReportStatusResolved ReportStatus = "RESOLVED"
+)
+
+type Report struct {
+	ID              string       `json:"id"`
+	ReporterID      string       `json:"reporterId"`
+	TargetRequestID *string      `json:"targetRequestId,omitempty"`
+	TargetUserID    *string      `json:"targetUserId,omitempty"`
+	Reason          ReportReason `json:"reason"`
+	Details         *string      `json:"details,omitempty"`
+	Status          ReportStatus `json:"status"`
+	CreatedAt       time.Time    `json:"createdAt"`
+	ResolvedAt      *time.Time   `json:"resolvedAt,omitempty"`
+}
+
+// CreateReportInput targets a request or a user; a zero AutoHideThreshold never hides.
+type CreateReportInput struct {
+	ReporterID        string
+	TargetRequestID   string
+	TargetUserID      string
+	Reason            ReportReason
+	Details           string
+	AutoHideThreshold int
+}
+
+type EnqueueModerationInput struct {
+	PrayerRequestID string
+	GroupID         *string
+	Reason          ModerationReason
+}
+
+// ModerationQueueFilter's scope fields are set by the service, never the request.
+type ModerationQueueFilter struct {
+	Statuses      []ModerationQueueStatus
+	GroupID       string
+	Reason        ModerationReason
+	PlatformScope bool
+	GroupIDs      []string
+	Limit         int
+	Offset        int
+}
+
+type ModerationAction struct {
+	ID              string               `json:"id"`
+	ActorUserID     string               `json:"actorUserId"`
+	ActorUsername   string               `json:"actorUsername,omitempty"`
+	TargetUserID    *string              `json:"targetUserId,omitempty"`
+	TargetRequestID *string              `json:"targetRequestId,omitempty"`
+	TargetGroupID   *string              `json:"targetGroupId,omitempty"`
+	Action          ModerationActionType `json:"action"`
+	Payload         map[string]any       `json:"payload"`
+	CreatedAt       time.Time            `json:"createdAt"`
+}
+
+// ModerationDecision is a single moderator action on a prayer request.
+type ModerationDecision struct {
+	ActorUserID string
+	Action      ModerationActionType
+	RequestID   string
+	QueueItemID string
+	GroupID     *string
+	// FromStatus, when set, limits the transition to that status.
+	FromStatus PrayerStatus
+	ToStatus   PrayerStatus
+	// ResolveAll closes every open item of the request.
+	ResolveAll bool
+	// WhenQueueClear waits for every group's review before applying ToStatus.
+	WhenQueueClear bool
+	// GroupOnly only takes the request out of GroupID.
+	GroupOnly bool
+	Reason    string
+	Note      string
+}
+
+// CommentDecision is a moderator's approve or reject on a held comment.
+type CommentDecision struct {
+	ActorUserID string
+	Action      ModerationActionType
+	QueueItemID string
+	CommentID   string
+	GroupID     *string
+	Reason      string
+	Note        string
+}
+
+// ProfileAudience is who may see a part of someone's profile.
+type ProfileAudience string
+
+const (
+	ProfileAudienceEveryone ProfileAudience = "EVERYONE"
+	ProfileAudienceFriends  ProfileAudience = "FRIENDS"
+	ProfileAudienceNobody   ProfileAudience = "NOBODY"
+)
+
+// FriendRequestPolicy is who may send someone a friend request.
+type FriendRequestPolicy string
+
+const (
+	FriendRequestsEveryone         FriendRequestPolicy = "EVERYONE"
+	FriendRequestsFriendsOfFriends FriendRequestPolicy = "FRIENDS_OF_FRIENDS"
+	FriendRequestsNobody           FriendRequestPolicy = "NOBODY"
+)
+
+type PrivacySettings struct {
+	ProfileVisibility ProfileAudience     `json:"profileVisibility"`
+	StatsVisibility   ProfileAudience     `json:"statsVisibility"`
+	FriendRequests    FriendRequestPolicy `json:"friendRequests"`
+	Searchable        bool                `json:"searchable"`
+}
+
+// DefaultPrivacySettings is the only place the privacy defaults are set.
+var DefaultPrivacySettings = PrivacySettings{
+	ProfileVisibility: ProfileAudienceEveryone,
+	StatsVisibility:   ProfileAudienceFriends,
+	FriendRequests:    FriendRequestsEveryone,
+	Searchable:        true,
+}
+
+// UpdatePrivacySettingsInput changes the fields that are set.
+type UpdatePrivacySettingsInput struct {
+	ProfileVisibility *ProfileAudience
+	StatsVisibility   *ProfileAudience
+	FriendRequests    *FriendRequestPolicy
+	Searchable        *bool
+}
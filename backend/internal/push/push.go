@@ -0,0 +1,270 @@
+// Package push sends encrypted, VAPID-signed Web Push messages.
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrGone means the subscription should be deleted.
+	ErrGone = errors.New("push: subscription gone")
+	// ErrRejected marks a permanent refusal; sending again will not help.
+	ErrRejected            = errors.New("push: message rejected")
+	ErrInvalidSubscription = errors.New("push: invalid subscription")
+)
+
+const (
+	// ttl is how long the push service keeps a message for an offline device.
+	ttl = 24 * time.Hour
+	// tokenLifetime stays under RFC 8292's 24-hour limit.
+	tokenLifetime = 12 * time.Hour
+	sendTimeout   = 30 * time.Second
+)
+
+// Subscription is what PushManager hands out, base64url encoded.
+type Subscription struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+// Sender implementations must be safe for concurrent use.
+type Sender interface {
+	Send(ctx context.Context, sub Subscription, payload []byte) error
+}
+
+// Config holds the base64url VAPID keys and the contact subject.
+type Config struct {
+	PublicKey  string
+	PrivateKey string
+	Subject    string
+	// HTTPClient defaults to one that only connects to public addresses.
+	HTTPClient *http.Client
+}
+
+// Client is the Sender that talks to real push services.
+type Client struct {
+	key        *ecdsa.PrivateKey
+	publicKey  string
+	subject    string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+func NewClient(cfg Config) (*Client, error) {
+	key, err := parseVAPIDKeys(cfg.PublicKey, cfg.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(cfg.Subject, "mailto:") && !strings.HasPrefix(cfg.Subject, "https:") {
+		return nil, errors.New("push: VAPID subject must be a mailto: or https: URL")
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: sendTimeout, Transport: publicTransport()}
+	}
+	return &Client{
+		key:        key,
+		publicKey:  strings.TrimRight(cfg.PublicKey, "="),
+		subject:    cfg.Subject,
+		httpClient: httpClient,
+		now:        time.Now,
+	}, nil
+}
+
+// Send returns ErrGone for 404 and 410 and ErrRejected for other refusals.
+func (c *Client) Send(ctx context.Context, sub Subscription, payload []byte) error {
+	keys, err := parseSubscription(sub)
+	if err != nil {
+		return err
+	}
+	body, err := encrypt(keys, payload)
+	if err != nil {
+		return err
+	}
+	token, err := c.token(keys.audience)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSubscription, err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl/time.Second)))
+	req.Header.Set("Urgency", "normal")
+	req.Header.Set("Authorization", "vapid t="+token+", k="+c.publicKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrGone
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized ||
+		resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusRequestEntityTooLarge:
+		return fmt.Errorf("%w: %s: %s", ErrRejected, resp.Status, strings.TrimSpace(string(detail)))
+	default:
+		return fmt.Errorf("push: %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+}
+
+// token signs a VAPID JWT for the push service at audience.
+func (c *Client) token(audience string) (string, error) {
+	claims := jwt.MapClaims{
+		"aud": audience,
+		"exp": c.now().Add(tokenLifetime).Unix(),
+		"sub": c.subject,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(c.key)
+}
+
+// ValidateSubscription requires https to a public host and usable keys.
+func ValidateSubscription(sub Subscription) error {
+	keys, err := parseSubscription(sub)
+	if err != nil {
+		return err
+	}
+	if keys.scheme != "https" {
+		return fmt.Errorf("%w: endpoint must use https", ErrInvalidSubscription)
+	}
+	if !publicHostname(keys.host) {
+		return fmt.Errorf("%w: endpoint host must be a public name", ErrInvalidSubscription)
+	}
+	return nil
+}
+
+// publicHostname rejects IP literals and names that only resolve locally.
+func publicHostname(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+		return false
+	}
+	for _, suffix := range []string{".localhost", ".local", ".internal", ".home.arpa"} {
+		if strings.HasSuffix(host, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// publicTransport refuses names that resolve into private networks.
+func publicTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return fmt.Errorf("%w: endpoint resolves to %s", ErrInvalidSubscription, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+func publicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace is RFC 6598 space, which IsPrivate misses.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+type subscriptionKeys struct {
+	scheme   string
+	host     string
+	audience string
+	p256dh   *ecdh.PublicKey
+	auth     []byte
+}
+
+func parseSubscription(sub Subscription) (subscriptionKeys, error) {
+	u, err := url.Parse(sub.Endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return subscriptionKeys{}, fmt.Errorf("%w: bad endpoint", ErrInvalidSubscription)
+	}
+	rawKey, err := decodeBase64(sub.P256dh)
+	if err != nil {
+		return subscriptionKeys{}, fmt.Errorf("%w: bad p256dh key", ErrInvalidSubscription)
+	}
+	p256dh, err := ecdh.P256().NewPublicKey(rawKey)
+	if err != nil {
+		return subscriptionKeys{}, fmt.Errorf("%w: bad p256dh key", ErrInvalidSubscription)
+	}
+	auth, err := decodeBase64(sub.Auth)
+	if err != nil || len(auth) != 16 {
+		return subscriptionKeys{}, fmt.Errorf("%w: bad auth secret", ErrInvalidSubscription)
+	}
+	return subscriptionKeys{
+		scheme:   u.Scheme,
+		host:     u.Hostname(),
+		audience: u.Scheme + "://" + u.Host,
+		p256dh:   p256dh,
+		auth:     auth,
+	}, nil
+}
+
+// parseVAPIDKeys checks that the two keys belong together.
+func parseVAPIDKeys(publicKey, privateKey string) (*ecdsa.PrivateKey, error) {
+	rawPrivate, err := decodeBase64(privateKey)
+	if err != nil {
+		return nil, errors.New("push: VAPID private key is not base64url")
+	}
+	private, err := ecdh.P256().NewPrivateKey(rawPrivate)
+	if err != nil {
+		return nil, fmt.Errorf("push: invalid VAPID private key: %v", err)
+	}
+	rawPublic, err := decodeBase64(publicKey)
+	if err != nil {
+		return nil, errors.New("push: VAPID public key is not base64url")
+	}
+	point := private.PublicKey().Bytes()
+	if !bytes.Equal(point, rawPublic) {
+		return nil, errors.New("push: VAPID public key does not match the private key")
+	}
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(point[1:33]),
+			Y:     new(big.Int).SetBytes(point[33:65]),
+		},
+		D: new(big.Int).SetBytes(rawPrivate),
+	}, nil
+}
+
+// decodeBase64 accepts base64url with or without padding.
+func decodeBase64(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(s), "="))
+}
@@ -0,0 +1,317 @@
+package push
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// browser plays the user agent side of a subscription.
+type browser struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newBrowser(t *testing.T) browser {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := make([]byte, 16)
+	if _, err = rand.Read(auth); err != nil {
+		t.Fatal(err)
+	}
+	return browser{key: key, auth: auth}
+}
+
+func (b browser) subscription(endpoint string) Subscription {
+	return Subscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(b.key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(b.auth),
+	}
+}
+
+// decrypt opens an aes128gcm body the way a browser does (RFC 8291).
+func (b browser) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	if len(body) < headerSize {
+		t.Fatalf("body is %d bytes, shorter than the header", len(body))
+	}
+	salt := body[:16]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != recordSize {
+		t.Fatalf("record size = %d, want %d", rs, recordSize)
+	}
+	if body[20] != 65 {
+		t.Fatalf("key id length = %d, want 65", body[20])
+	}
+	asPublic, err := ecdh.P256().NewPublicKey(body[21:86])
+	if err != nil {
+		t.Fatalf("sender key: %v", err)
+	}
+	secret, err := b.key.ECDH(asPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyInfo := append(append([]byte("WebPush: info\x00"), b.key.PublicKey().Bytes()...), asPublic.Bytes()...)
+	ikm := hkdf(b.auth, secret, keyInfo, 32)
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := gcm.Open(nil, nonce, body[headerSize:], nil)
+	if err != nil {
+		t.Fatalf("open record: %v", err)
+	}
+	if n := len(plaintext); n == 0 || plaintext[n-1] != 0x02 {
+		t.Fatalf("record does not end with the last-record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+func newVAPIDConfig(t *testing.T) Config {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Config{
+		PublicKey:  base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		PrivateKey: base64.RawURLEncoding.EncodeToString(key.Bytes()),
+		Subject:    "mailto:push@example.com",
+	}
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	b := newBrowser(t)
+	keys, err := parseSubscription(b.subscription("https://push.example.com/send/abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, payload := range [][]byte{{}, []byte(`{"title":"Creo"}`), make([]byte, MaxPayload)} {
+		body, err := encrypt(keys, payload)
+		if err != nil {
+			t.Fatalf("encrypt %d bytes: %v", len(payload), err)
+		}
+		if len(body) > recordSize {
+			t.Fatalf("body is %d bytes, more than one record", len(body))
+		}
+		if got := b.decrypt(t, body); string(got) != string(payload) {
+			t.Fatalf("decrypted %q, want %q", got, payload)
+		}
+	}
+
+	if _, err = encrypt(keys, make([]byte, MaxPayload+1)); !errors.Is(err, ErrRejected) {
+		t.Fatalf("oversized payload: err = %v, want ErrRejected", err)
+	}
+}
+
+func TestSendSignsWithVAPID(t *testing.T) {
+	b := newBrowser(t)
+	var (
+		header http.Header
+		body   []byte
+	)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cfg := newVAPIDConfig(t)
+	cfg.HTTPClient = server.Client()
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	client.now = func() time.Time { return now }
+
+	if err = client.Send(context.Background(), b.subscription(server.URL+"/send/abc"), []byte("hello")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"Content-Type":     "application/octet-stream",
+		"Content-Encoding": "aes128gcm",
+		"TTL":              "86400",
+	} {
+		if got := header.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if got := b.decrypt(t, body); string(got) != "hello" {
+		t.Errorf("payload = %q, want %q", got, "hello")
+	}
+
+	auth := header.Get("Authorization")
+	token, publicKey, ok := strings.Cut(strings.TrimPrefix(auth, "vapid t="), ", k=")
+	if !strings.HasPrefix(auth, "vapid t=") || !ok {
+		t.Fatalf("Authorization = %q, want vapid t=..., k=...", auth)
+	}
+	if publicKey != cfg.PublicKey {
+		t.Errorf("k = %q, want %q", publicKey, cfg.PublicKey)
+	}
+	verifyKey, err := parseVAPIDKeys(cfg.PublicKey, cfg.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return &verifyKey.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}), jwt.WithTimeFunc(func() time.Time { return now }))
+	if err != nil {
+		t.Fatalf("token does not verify: %v", err)
+	}
+	if claims["aud"] != server.URL {
+		t.Errorf("aud = %v, want %s", claims["aud"], server.URL)
+	}
+	if claims["sub"] != cfg.Subject {
+		t.Errorf("sub = %v, want %s", claims["sub"], cfg.Subject)
+	}
+	if exp, _ := claims.GetExpirationTime(); exp == nil || !exp.Equal(now.Add(tokenLifetime)) {
+		t.Errorf("exp = %v, want %v", exp, now.Add(tokenLifetime))
+	}
+}
+
+func TestSendStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusCreated, nil},
+		{http.StatusNotFound, ErrGone},
+		{http.StatusGone, ErrGone},
+		{http.StatusRequestEntityTooLarge, ErrRejected},
+		{http.StatusForbidden, ErrRejected},
+	}
+	b := newBrowser(t)
+	for _, tt := range tests {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+		cfg := newVAPIDConfig(t)
+		cfg.HTTPClient = server.Client()
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = client.Send(context.Background(), b.subscription(server.URL), []byte("hi"))
+		server.Close()
+		if !errors.Is(err, tt.want) {
+			t.Errorf("status %d: err = %v, want %v", tt.status, err, tt.want)
+		}
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	cfg := newVAPIDConfig(t)
+	cfg.HTTPClient = server.Client()
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.Send(context.Background(), b.subscription(server.URL), []byte("hi"))
+	if err == nil || errors.Is(err, ErrGone) || errors.Is(err, ErrRejected) {
+		t.Errorf("status 503: err = %v, want a temporary error", err)
+	}
+}
+
+func TestDefaultClientRefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached a loopback server")
+	}))
+	defer server.Close()
+
+	cfg := newVAPIDConfig(t)
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.Send(context.Background(), newBrowser(t).subscription(server.URL), []byte("hi"))
+	if !errors.Is(err, ErrInvalidSubscription) {
+		t.Fatalf("err = %v, want ErrInvalidSubscription", err)
+	}
+}
+
+func TestValidateSubscription(t *testing.T) {
+	b := newBrowser(t)
+	tests := []struct {
+		endpoint string
+		ok       bool
+	}{
+		{"https://fcm.googleapis.com/fcm/send/abc", true},
+		{"https://updates.push.services.mozilla.com/wpush/v2/abc", true},
+		{"https://push.example.com:8443/abc", true},
+		{"http://fcm.googleapis.com/fcm/send/abc", false},
+		{"https://127.0.0.1/abc", false},
+		{"https://10.0.0.5/abc", false},
+		{"https://169.254.169.254/latest/meta-data", false},
+		{"https://[::1]/abc", false},
+		{"https://localhost/abc", false},
+		{"https://api.localhost/abc", false},
+		{"https://metadata.google.internal/abc", false},
+		{"https://printer.local/abc", false},
+		{"https://intranet/abc", false},
+		{"not a url", false},
+	}
+	for _, tt := range tests {
+		err := ValidateSubscription(b.subscription(tt.endpoint))
+		if tt.ok && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.endpoint, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidSubscription) {
+			t.Errorf("%s: err = %v, want ErrInvalidSubscription", tt.endpoint, err)
+		}
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"8.8.8.8", true},
+		{"2607:f8b0:4004:c07::5f", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"100.64.0.1", false},
+		{"169.254.169.254", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+	}
+	for _, tt := range tests {
+		if got := publicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("publicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
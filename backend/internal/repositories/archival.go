@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"parish-viva/backend/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// archivalLockKey keeps replicas from archiving at the same time.
+const archivalLockKey int64 = 0x7072617961726368
+
+// archivalBatchSize keeps each pass to a short transaction.
+const archivalBatchSize = 500
+
+type archivalCandidate struct {
+	requestID string
+	authorID  string
+	at        time.Time
+}
+
+// ArchiveStalePrayerRequests warns, then archives, idle requests; it is safe to rerun.
+func (r *PostgresRepository) ArchiveStalePrayerRequests(ctx context.Context, policy models.ArchivalPolicy) (models.ArchivalResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.ArchivalResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err = tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, archivalLockKey).Scan(&locked); err != nil {
+		return models.ArchivalResult{}, err
+	}
+	if !locked {
+		return models.ArchivalResult{}, nil
+	}
+
+	inactiveSeconds := int64(policy.InactiveFor / time.Second)
+	warnSeconds := int64(policy.WarnBefore / time.Second)
+
+	rows, err := tx.Query(ctx, `
+		UPDATE prayer_requests
+		SET archive_warned_at = NOW()
+		WHERE id IN (
+			SELECT id
+			FROM prayer_requests
+			WHERE status = 'ACTIVE'
+			  AND deleted_at IS NULL
+			  AND archive_warned_at IS NULL
+			  AND last_activity_at < NOW() - ($1::bigint - $2::bigint) * INTERVAL '1 second'
+			ORDER BY last_activity_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id::text, author_id::text,
+			GREATEST(last_activity_at + $1::bigint * INTERVAL '1 second', NOW() + $2::bigint * INTERVAL '1 second')
+	`, inactiveSeconds, warnSeconds, archivalBatchSize)
+	if err != nil {
+		return models.ArchivalResult{}, err
+	}
+	warned, err := scanArchivalCandidates(rows)
+	if err != nil {
+		return models.ArchivalResult{}, err
+	}
+	for _, c := range warned {
+		_ = insertNotificationInTx(ctx, tx, models.CreateNotificationInput{
+			UserID:      c.authorID,
+			Type:        models.NotificationTypeRequestArchiveWarning,
+			SubjectType: models.NotificationSubjectPrayerRequest,
+			SubjectID:   c.requestID,
+			Payload:     map[string]any{"archivesAt": c.at},
+		})
+	}
+
+	rows, err = tx.Query(ctx, `
+		UPDATE prayer_requests
+		SET status = 'ARCHIVED', archived_at = NOW(), updated_at = NOW()
+		WHERE id IN (
+			SELECT id
+			FROM prayer_requests
+			WHERE status = 'ACTIVE'
+			  AND deleted_at IS NULL
+			  AND archive_warned_at <= NOW() - $2::bigint * INTERVAL '1 second'
+			  AND last_activity_at < NOW() - $1::bigint * INTERVAL '1 second'
+			ORDER BY last_activity_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id::text, author_id::text, archived_at
+	`, inactiveSeconds, warnSeconds, archivalBatchSize)
+	if err != nil {
+		return models.ArchivalResult{}, err
+	}
+	archived, err := scanArchivalCandidates(rows)
+	if err != nil {
+		return models.ArchivalResult{}, err
+	}
+	for _, c := range archived {
+		_ = insertNotificationInTx(ctx, tx, models.CreateNotificationInput{
+			UserID:      c.authorID,
+			Type:        models.NotificationTypeRequestArchived,
+			SubjectType: models.NotificationSubjectPrayerRequest,
+			SubjectID:   c.requestID,
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.ArchivalResult{}, err
+	}
+	return models.ArchivalResult{Warned: len(warned), Archived: len(archived)}, nil
+}
+
+// KeepPrayerRequestActive renews a request, un-archiving it if needed.
+func (r *PostgresRepository) KeepPrayerRequestActive(ctx context.Context, authorID, requestID string) error {
+	var ownerID string
+	err := r.db.QueryRow(ctx, `
+		SELECT author_id::text
+		FROM prayer_requests
+		WHERE id = $1 AND deleted_at IS NULL
+	`, requestID).Scan(&ownerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPrayerRequestNotFound
+		}
+		return err
+	}
+	if ownerID != authorID {
+		return ErrPrayerRequestForbidden
+	}
+
+	ct, err := r.db.Exec(ctx, `
+		UPDATE prayer_requests
+		SET status = 'ACTIVE',
+			last_activity_at = NOW(),
+			archive_warned_at = NULL,
+			archived_at = NULL,
+			updated_at = NOW()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		  AND status IN ('ACTIVE', 'ARCHIVED')
+	`, requestID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return ErrPrayerRequestNotActive
+	}
+	return nil
+}
+
+func scanArchivalCandidates(rows pgx.Rows) ([]archivalCandidate, error) {
+	defer rows.Close()
+	items := make([]archivalCandidate, 0)
+	for rows.Next() {
+		var c archivalCandidate
+		if err := rows.Scan(&c.requestID, &c.authorID, &c.at); err != nil {
+			return nil, err
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"parish-viva/backend/internal/models"
+)
+
+var ErrCannotBlockSelf = errors.New("cannot block self")
+var ErrBlockNotFound = errors.New("block not found")
+
+// blockedBetweenSQL is true when either user (SQL uuid expressions) blocked the other.
+func blockedBetweenSQL(a, b string) string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM friendships blk
+		WHERE blk.status = 'BLOCKED'
+		  AND ((blk.user_id = %[1]s AND blk.friend_user_id = %[2]s)
+		    OR (blk.user_id = %[2]s AND blk.friend_user_id = %[1]s))
+	)`, a, b)
+}
+
+func (r *PostgresRepository) IsBlocked(ctx context.Context, userID, otherUserID string) (bool, error) {
+	var blocked bool
+	err := r.db.QueryRow(ctx, `SELECT `+blockedBetweenSQL("$1::uuid", "$2::uuid"), userID, otherUserID).Scan(&blocked)
+	return blocked, err
+}
+
+// BlockUser ends any friendship and drops the notifications between the two.
+func (r *PostgresRepository) BlockUser(ctx context.Context, userID, username string) (models.BlockedUser, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.BlockedUser{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var b models.BlockedUser
+	err = tx.QueryRow(ctx, `
+		SELECT id::text, username, display_name, avatar_url
+		FROM users
+		WHERE LOWER(username) = LOWER($1) AND deleted_at IS NULL
+	`, username).Scan(&b.UserID, &b.Username, &b.DisplayName, &b.AvatarURL)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.BlockedUser{}, ErrUserNotFound
+		}
+		return models.BlockedUser{}, err
+	}
+	if b.UserID == userID {
+		return models.BlockedUser{}, ErrCannotBlockSelf
+	}
+
+	var ended []string
+	err = tx.QueryRow(ctx, `
+		WITH ended AS (
+			DELETE FROM friendships
+			WHERE status <> 'BLOCKED'
+			  AND ((user_id = $1 AND friend_user_id = $2) OR (user_id = $2 AND friend_user_id = $1))
+			RETURNING id
+		)
+		SELECT COALESCE(array_agg(id::text), '{}') FROM ended
+	`, userID, b.UserID).Scan(&ended)
+	if err != nil {
+		return models.BlockedUser{}, err
+	}
+	err = deleteNotificationsWhere(ctx, tx, `
+		(subject_type = 'FRIENDSHIP' AND subject_id = ANY($1::uuid[]))
+		OR (user_id = $2 AND actor_user_id = $3)
+		OR (user_id = $3 AND actor_user_id = $2)
+	`, ended, userID, b.UserID)
+	if err != nil {
+		return models.BlockedUser{}, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO friendships (user_id, friend_user_id, status)
+		VALUES ($1, $2, 'BLOCKED')
+		ON CONFLICT (user_id, friend_user_id) DO UPDATE SET updated_at = friendships.updated_at
+		RETURNING created_at
+	`, userID, b.UserID).Scan(&b.BlockedAt)
+	if err != nil {
+		return models.BlockedUser{}, err
+	}
+	return b, tx.Commit(ctx)
+}
+
+// UnblockUser lifts the block userID placed on username.
+func (r *PostgresRepository) UnblockUser(ctx context.Context, userID, username string) error {
+	ct, err := r.db.Exec(ctx, `
+		DELETE FROM friendships f
+		USING users u
+		WHERE f.user_id = $1
+		  AND f.friend_user_id = u.id
+		  AND f.status = 'BLOCKED'
+		  AND LOWER(u.username) = LOWER($2)
+	`, userID, username)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return ErrBlockNotFound
+	}
+	return nil
+}
+
+// ListBlockedUsers returns the people userID blocked, latest first.
+func (r *PostgresRepository) ListBlockedUsers(ctx context.Context, userID string) ([]models.BlockedUser, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT u.id::text, u.username, u.display_name, u.avatar_url, f.created_at
+		FROM friendships f
+		INNER JOIN users u ON u.id = f.friend_user_id
+		WHERE f.user_id = $1
+		  AND f.status = 'BLOCKED'
+		  AND u.deleted_at IS NULL
+		ORDER BY f.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.BlockedUser, 0)
+	for rows.Next() {
+		var item models.BlockedUser
+		if err = rows.Scan(&item.UserID, &item.Username, &item.DisplayName, &item.AvatarURL, &item.BlockedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,504 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"parish-viva/backend/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateComment holds the comment for review when a group requires it.
+func (r *PostgresRepository) CreateComment(ctx context.Context, in models.CreateCommentInput) (models.Comment, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.Comment{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if in.ParentID != "" {
+		var ok bool
+		err = tx.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1
+				FROM prayer_request_comments
+				WHERE id = $1
+				  AND prayer_request_id = $2
+				  AND parent_id IS NULL
+				  AND status = 'VISIBLE'
+				  AND deleted_at IS NULL
+			)
+		`, in.ParentID, in.RequestID).Scan(&ok)
+		if err != nil {
+			return models.Comment{}, err
+		}
+		if !ok {
+			return models.Comment{}, ErrInvalidCommentParent
+		}
+	}
+
+	status := models.CommentStatusVisible
+	if len(in.ReviewGroupIDs) > 0 {
+		status = models.CommentStatusPendingReview
+	}
+
+	var commentID string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO prayer_request_comments (prayer_request_id, author_id, parent_id, body, status)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5)
+		RETURNING id::text
+	`, in.RequestID, in.AuthorID, in.ParentID, in.Body, string(status)).Scan(&commentID)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	for _, groupID := range in.ReviewGroupIDs {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO moderation_queue (comment_id, group_id, reason, status)
+			VALUES ($1, $2, $3, 'PENDING')
+			ON CONFLICT (comment_id, group_id) WHERE comment_id IS NOT NULL AND status <> 'RESOLVED'
+			DO NOTHING
+		`, commentID, groupID, string(models.ReasonGroupModeration))
+		if err != nil {
+			return models.Comment{}, err
+		}
+	}
+
+	if status == models.CommentStatusVisible {
+		if err = publishCommentOn(ctx, tx, commentID); err != nil {
+			return models.Comment{}, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Comment{}, err
+	}
+	return r.getComment(ctx, commentID)
+}
+
+// publishCommentOn counts, bumps and notifies for a newly visible comment.
+func publishCommentOn(ctx context.Context, tx pgx.Tx, commentID string) error {
+	var (
+		requestID       string
+		commenterID     string
+		parentID        *string
+		requestAuthorID string
+		parentAuthorID  *string
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT c.prayer_request_id::text, c.author_id::text, c.parent_id::text, pr.author_id::text, p.author_id::text
+		FROM prayer_request_comments c
+		INNER JOIN prayer_requests pr ON pr.id = c.prayer_request_id
+		LEFT JOIN prayer_request_comments p ON p.id = c.parent_id AND p.deleted_at IS NULL
+		WHERE c.id = $1
+	`, commentID).Scan(&requestID, &commenterID, &parentID, &requestAuthorID, &parentAuthorID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE prayer_requests
+		SET comment_count = comment_count + 1,
+			last_activity_at = CASE WHEN status = 'ACTIVE' THEN NOW() ELSE last_activity_at END,
+			archive_warned_at = CASE WHEN status = 'ACTIVE' THEN NULL ELSE archive_warned_at END
+		WHERE id = $1
+	`, requestID)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{"commentId": commentID}
+	if parentID != nil {
+		payload["parentId"] = *parentID
+	}
+	recipients := []string{requestAuthorID}
+	if parentAuthorID != nil && *parentAuthorID != requestAuthorID {
+		recipients = append(recipients, *parentAuthorID)
+	}
+	actor := commenterID
+	for _, userID := range recipients {
+		if userID == commenterID {
+			continue
+		}
+		_ = insertNotificationInTx(ctx, tx, models.CreateNotificationInput{
+			UserID:      userID,
+			Type:        models.NotificationTypeCommentReceived,
+			ActorUserID: &actor,
+			SubjectType: models.NotificationSubjectPrayerRequest,
+			SubjectID:   requestID,
+			Payload:     payload,
+		})
+	}
+	return nil
+}
+
+// unpublishCommentOn takes a comment out of the request's count.
+func unpublishCommentOn(ctx context.Context, tx pgx.Tx, requestID string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE prayer_requests
+		SET comment_count = GREATEST(comment_count - 1, 0)
+		WHERE id = $1
+	`, requestID)
+	return err
+}
+
+// commentReplySample is how many replies ListComments loads per comment.
+const commentReplySample = 10
+
+// ListComments lists top-level comments, oldest first, with their first replies.
+func (r *PostgresRepository) ListComments(ctx context.Context, viewerID, requestID string, limit, offset int) ([]models.Comment, int64, error) {
+	var total int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)::bigint
+		FROM prayer_request_comments
+		WHERE prayer_request_id = $1
+		  AND parent_id IS NULL
+		  AND deleted_at IS NULL
+		  AND (status = 'VISIBLE' OR (status = 'PENDING_REVIEW' AND author_id = NULLIF($2, '')::uuid))
+	`, requestID, viewerID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id::text, c.prayer_request_id::text, c.parent_id::text, c.author_id::text,
+		       u.username, u.display_name, u.avatar_url, c.body, c.status, c.created_at, c.updated_at
+		FROM prayer_request_comments c
+		INNER JOIN users u ON u.id = c.author_id
+		WHERE c.prayer_request_id = $1
+		  AND c.parent_id IS NULL
+		  AND c.deleted_at IS NULL
+		  AND (c.status = 'VISIBLE' OR (c.status = 'PENDING_REVIEW' AND c.author_id = NULLIF($2, '')::uuid))
+		ORDER BY c.created_at ASC, c.id ASC
+		LIMIT $3 OFFSET $4
+	`, requestID, viewerID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	items, err := scanComments(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(items) == 0 {
+		return items, total, nil
+	}
+
+	parentIDs := make([]string, 0, len(items))
+	indexByID := make(map[string]int, len(items))
+	for i := range items {
+		parentIDs = append(parentIDs, items[i].ID)
+		indexByID[items[i].ID] = i
+	}
+	// One reply past the sample tells whether there are more.
+	replyRows, err := r.db.Query(ctx, `
+		SELECT c.id::text, c.prayer_request_id::text, c.parent_id::text, c.author_id::text,
+		       c.username, c.display_name, c.avatar_url, c.body, c.status, c.created_at, c.updated_at
+		FROM unnest($1::uuid[]) AS p(id)
+		CROSS JOIN LATERAL (
+			SELECT c.*, u.username, u.display_name, u.avatar_url
+			FROM prayer_request_comments c
+			INNER JOIN users u ON u.id = c.author_id
+			WHERE c.parent_id = p.id
+			  AND c.deleted_at IS NULL
+			  AND (c.status = 'VISIBLE' OR (c.status = 'PENDING_REVIEW' AND c.author_id = NULLIF($2, '')::uuid))
+			ORDER BY c.created_at ASC, c.id ASC
+			LIMIT $3
+		) c
+		ORDER BY c.created_at ASC, c.id ASC
+	`, parentIDs, viewerID, commentReplySample+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	replies, err := scanComments(replyRows)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, reply := range replies {
+		idx := indexByID[*reply.ParentID]
+		if len(items[idx].Replies) == commentReplySample {
+			items[idx].HasMoreReplies = true
+			continue
+		}
+		items[idx].Replies = append(items[idx].Replies, reply)
+	}
+	return items, total, nil
+}
+
+// ListCommentReplies pages replies with ListComments' visibility rules.
+func (r *PostgresRepository) ListCommentReplies(ctx context.Context, viewerID, requestID, commentID string, limit, offset int) ([]models.Comment, int64, error) {
+	var total int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(c.id)::bigint
+		FROM prayer_request_comments p
+		LEFT JOIN prayer_request_comments c
+			ON c.parent_id = p.id
+			AND c.deleted_at IS NULL
+			AND (c.status = 'VISIBLE' OR (c.status = 'PENDING_REVIEW' AND c.author_id = NULLIF($3, '')::uuid))
+		WHERE p.id = $1
+		  AND p.prayer_request_id = $2
+		  AND p.parent_id IS NULL
+		  AND p.deleted_at IS NULL
+		  AND (p.status = 'VISIBLE' OR (p.status = 'PENDING_REVIEW' AND p.author_id = NULLIF($3, '')::uuid))
+		GROUP BY p.id
+	`, commentID, requestID, viewerID).Scan(&total)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, ErrCommentNotFound
+		}
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id::text, c.prayer_request_id::text, c.parent_id::text, c.author_id::text,
+		       u.username, u.display_name, u.avatar_url, c.body, c.status, c.created_at, c.updated_at
+		FROM prayer_request_comments c
+		INNER JOIN users u ON u.id = c.author_id
+		WHERE c.parent_id = $1
+		  AND c.deleted_at IS NULL
+		  AND (c.status = 'VISIBLE' OR (c.status = 'PENDING_REVIEW' AND c.author_id = NULLIF($2, '')::uuid))
+		ORDER BY c.created_at ASC, c.id ASC
+		LIMIT $3 OFFSET $4
+	`, commentID, viewerID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	items, err := scanComments(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// DeleteComment lets the comment's or the request's author delete it.
+func (r *PostgresRepository) DeleteComment(ctx context.Context, userID, requestID, commentID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		commenterID     string
+		requestAuthorID string
+		status          models.CommentStatus
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT c.author_id::text, pr.author_id::text, c.status
+		FROM prayer_request_comments c
+		INNER JOIN prayer_requests pr ON pr.id = c.prayer_request_id
+		WHERE c.id = $1
+		  AND c.prayer_request_id = $2
+		  AND c.deleted_at IS NULL
+		FOR UPDATE OF c
+	`, commentID, requestID).Scan(&commenterID, &requestAuthorID, &status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+	if userID != commenterID && userID != requestAuthorID {
+		return ErrCommentForbidden
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE prayer_request_comments
+		SET deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, commentID)
+	if err != nil {
+		return err
+	}
+	if status == models.CommentStatusVisible {
+		if err = unpublishCommentOn(ctx, tx, requestID); err != nil {
+			return err
+		}
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE moderation_queue
+		SET status = 'RESOLVED', resolved_at = NOW(), resolved_by = $2, updated_at = NOW()
+		WHERE comment_id = $1 AND status <> 'RESOLVED'
+	`, commentID, userID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ApplyCommentDecision publishes a comment once every reviewing group approves.
+func (r *PostgresRepository) ApplyCommentDecision(ctx context.Context, d models.CommentDecision) (models.ModerationAction, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		queueStatus models.ModerationQueueStatus
+		claimedBy   *string
+		claimStale  bool
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT status, claimed_by::text, COALESCE(claimed_at < NOW() - INTERVAL '30 minutes', TRUE)
+		FROM moderation_queue
+		WHERE id = $1 AND comment_id = $2
+		FOR UPDATE
+	`, d.QueueItemID, d.CommentID).Scan(&queueStatus, &claimedBy, &claimStale)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ModerationAction{}, ErrModerationItemNotFound
+		}
+		return models.ModerationAction{}, err
+	}
+	if queueStatus == models.QueueStatusResolved {
+		return models.ModerationAction{}, ErrModerationItemResolved
+	}
+	if claimedBy != nil && *claimedBy != d.ActorUserID && !claimStale {
+		return models.ModerationAction{}, ErrModerationItemClaimed
+	}
+
+	var (
+		commenterID string
+		requestID   string
+		fromStatus  models.CommentStatus
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT author_id::text, prayer_request_id::text, status
+		FROM prayer_request_comments
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`, d.CommentID).Scan(&commenterID, &requestID, &fromStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ModerationAction{}, ErrCommentNotFound
+		}
+		return models.ModerationAction{}, err
+	}
+
+	toStatus := fromStatus
+	if d.Action == models.ActionReject {
+		_, err = tx.Exec(ctx, `
+			UPDATE moderation_queue
+			SET status = 'RESOLVED', resolved_at = NOW(), resolved_by = $2, updated_at = NOW()
+			WHERE comment_id = $1 AND status <> 'RESOLVED'
+		`, d.CommentID, d.ActorUserID)
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+		toStatus = models.CommentStatusRemoved
+	} else {
+		_, err = tx.Exec(ctx, `
+			UPDATE moderation_queue
+			SET status = 'RESOLVED', resolved_at = NOW(), resolved_by = $2, updated_at = NOW()
+			WHERE id = $1
+		`, d.QueueItemID, d.ActorUserID)
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+		var stillOpen bool
+		err = tx.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM moderation_queue
+				WHERE comment_id = $1 AND status <> 'RESOLVED'
+			)
+		`, d.CommentID).Scan(&stillOpen)
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+		if !stillOpen && fromStatus == models.CommentStatusPendingReview {
+			toStatus = models.CommentStatusVisible
+		}
+	}
+
+	if toStatus != fromStatus {
+		_, err = tx.Exec(ctx, `
+			UPDATE prayer_request_comments
+			SET status = $2, updated_at = NOW()
+			WHERE id = $1
+		`, d.CommentID, string(toStatus))
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+		switch {
+		case toStatus == models.CommentStatusVisible:
+			err = publishCommentOn(ctx, tx, d.CommentID)
+		case fromStatus == models.CommentStatusVisible:
+			err = unpublishCommentOn(ctx, tx, requestID)
+		}
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+	}
+
+	// commentStatus keeps comment actions from looking revertible.
+	payload := map[string]any{
+		"commentId":     d.CommentID,
+		"queueItemId":   d.QueueItemID,
+		"commentStatus": string(toStatus),
+	}
+	if d.Reason != "" {
+		payload["reason"] = d.Reason
+	}
+	if d.Note != "" {
+		payload["note"] = d.Note
+	}
+	author := commenterID
+	action, err := insertModerationActionOn(ctx, tx, models.ModerationAction{
+		ActorUserID:     d.ActorUserID,
+		TargetUserID:    &author,
+		TargetRequestID: &requestID,
+		TargetGroupID:   d.GroupID,
+		Action:          d.Action,
+		Payload:         payload,
+	})
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.ModerationAction{}, err
+	}
+	return action, nil
+}
+
+func (r *PostgresRepository) getComment(ctx context.Context, commentID string) (models.Comment, error) {
+	items, err := r.loadComments(ctx, []string{commentID})
+	if err != nil {
+		return models.Comment{}, err
+	}
+	if len(items) == 0 {
+		return models.Comment{}, ErrCommentNotFound
+	}
+	return items[0], nil
+}
+
+// loadComments ignores status, for moderators and authors.
+func (r *PostgresRepository) loadComments(ctx context.Context, commentIDs []string) ([]models.Comment, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id::text, c.prayer_request_id::text, c.parent_id::text, c.author_id::text,
+		       u.username, u.display_name, u.avatar_url, c.body, c.status, c.created_at, c.updated_at
+		FROM prayer_request_comments c
+		INNER JOIN users u ON u.id = c.author_id
+		WHERE c.id::text = ANY($1::text[])
+		  AND c.deleted_at IS NULL
+	`, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+	return scanComments(rows)
+}
+
+func scanComments(rows pgx.Rows) ([]models.Comment, error) {
+	defer rows.Close()
+	items := make([]models.Comment, 0)
+	for rows.Next() {
+		var c models.Comment
+		if err := rows.Scan(&c.ID, &c.PrayerRequestID, &c.ParentID, &c.AuthorID, &c.AuthorUsername, &c.AuthorDisplayName,
+			&c.AuthorAvatarURL, &c.Body, &c.Status, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"parish-viva/backend/internal/models"
+)
+
+// ClaimDueDigests stamps the users whose sendHour slot has passed.
+func (r *PostgresRepository) ClaimDueDigests(ctx context.Context, sendHour, limit int) ([]models.DigestRecipient, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT np.user_id, np.digest_last_sent_at AS previous_sent_at
+			FROM notification_preferences np
+			INNER JOIN users u ON u.id = np.user_id
+			CROSS JOIN LATERAL (
+				SELECT (NOW() AT TIME ZONE np.timezone) - make_interval(hours => $1) AS shifted
+			) l
+			CROSS JOIN LATERAL (
+				SELECT date_trunc(CASE np.email_digest WHEN 'WEEKLY' THEN 'week' ELSE 'day' END, l.shifted)
+					+ make_interval(hours => $1) AS local_slot
+			) s
+			WHERE np.email_digest <> 'OFF'
+			  AND u.email_notifications_enabled
+			  AND u.deleted_at IS NULL
+			  AND (np.digest_last_sent_at IS NULL OR np.digest_last_sent_at < (s.local_slot AT TIME ZONE np.timezone))
+			ORDER BY np.digest_last_sent_at NULLS FIRST
+			LIMIT $2
+			FOR UPDATE OF np SKIP LOCKED
+		)
+		UPDATE notification_preferences np
+		SET digest_last_sent_at = NOW()
+		FROM due
+		WHERE np.user_id = due.user_id
+		RETURNING np.user_id::text, np.email_digest, np.unsubscribe_token::text, due.previous_sent_at
+	`, sendHour, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.DigestRecipient, 0)
+	for rows.Next() {
+		var d models.DigestRecipient
+		if err = rows.Scan(&d.UserID, &d.Frequency, &d.UnsubscribeToken, &d.PreviousSentAt); err != nil {
+			return nil, err
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+// RestoreDigestSchedule lets the next pass retry an unsent digest.
+func (r *PostgresRepository) RestoreDigestSchedule(ctx context.Context, userID string, previousSentAt *time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notification_preferences
+		SET digest_last_sent_at = $2
+		WHERE user_id = $1
+	`, userID, previousSentAt)
+	return err
+}
+
+func (r *PostgresRepository) GetEmailDigest(ctx context.Context, userID string, since time.Time, limit int) (models.EmailDigest, error) {
+	var d models.EmailDigest
+	err := r.db.QueryRow(ctx, `
+		SELECT email, display_name, locale
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+	`, userID).Scan(&d.RecipientEmail, &d.RecipientName, &d.Locale)
+	if err != nil {
+		return models.EmailDigest{}, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT n.id::text, n.type, n.subject_type, n.subject_id::text,
+		       COALESCE(pr.title, g.name, ''), n.payload,
+		       CASE WHEN pr.allow_anonymous AND pr.author_id = a.id THEN '' ELSE COALESCE(a.display_name, '') END,
+		       n.created_at, COUNT(*) OVER ()
+		FROM notifications n
+		LEFT JOIN users a ON a.id = n.actor_user_id AND a.deleted_at IS NULL
+		LEFT JOIN prayer_requests pr ON n.subject_type = 'PRAYER_REQUEST' AND pr.id = n.subject_id
+		LEFT JOIN groups g ON n.subject_type = 'GROUP' AND g.id = n.subject_id
+		WHERE n.user_id = $1 AND n.in_app AND n.read_at IS NULL AND n.created_at >= $2
+		ORDER BY n.created_at DESC
+		LIMIT $3
+	`, userID, since, limit)
+	if err != nil {
+		return models.EmailDigest{}, err
+	}
+	defer rows.Close()
+	d.Notifications = make([]models.NotificationEmail, 0)
+	for rows.Next() {
+		var (
+			n            models.NotificationEmail
+			payloadBytes []byte
+		)
+		err = rows.Scan(&n.NotificationID, &n.Type, &n.SubjectType, &n.SubjectID,
+			&n.SubjectTitle, &payloadBytes, &n.ActorName, &n.CreatedAt, &d.UnreadCount)
+		if err != nil {
+			return models.EmailDigest{}, err
+		}
+		n.Payload = map[string]any{}
+		if len(payloadBytes) > 0 {
+			if err = json.Unmarshal(payloadBytes, &n.Payload); err != nil {
+				return models.EmailDigest{}, err
+			}
+		}
+		d.Notifications = append(d.Notifications, n)
+	}
+	if err = rows.Err(); err != nil {
+		return models.EmailDigest{}, err
+	}
+
+	rows, err = r.db.Query(ctx, `
+		SELECT pr.id::text, pr.title, MIN(g.name), COUNT(*) OVER ()
+		FROM prayer_requests pr
+		INNER JOIN prayer_request_groups prg ON prg.prayer_request_id = pr.id
+		INNER JOIN group_memberships gm ON gm.group_id = prg.group_id
+		INNER JOIN groups g ON g.id = prg.group_id AND g.deleted_at IS NULL
+		WHERE gm.user_id = $1
+		  AND gm.deleted_at IS NULL
+		  AND pr.deleted_at IS NULL
+		  AND pr.status = 'ACTIVE'
+		  AND pr.author_id <> $1
+		  AND pr.created_at >= $2
+		  AND pr.tradition = (SELECT tradition FROM users WHERE id = $1 AND deleted_at IS NULL)
+		GROUP BY pr.id
+		ORDER BY pr.created_at DESC
+		LIMIT $3
+	`, userID, since, limit)
+	if err != nil {
+		return models.EmailDigest{}, err
+	}
+	defer rows.Close()
+	d.Requests = make([]models.DigestRequest, 0)
+	for rows.Next() {
+		var req models.DigestRequest
+		if err = rows.Scan(&req.RequestID, &req.Title, &req.GroupName, &d.RequestCount); err != nil {
+			return models.EmailDigest{}, err
+		}
+		d.Requests = append(d.Requests, req)
+	}
+	return d, rows.Err()
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"parish-viva/backend/internal/models"
+)
+
+// ClaimNotificationEmails leases due emails so other replicas skip them.
+func (r *PostgresRepository) ClaimNotificationEmails(ctx context.Context, limit int, lease time.Duration) ([]models.NotificationEmail, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT id
+			FROM notifications
+			WHERE sent_email_at IS NULL
+			  AND email_skipped_at IS NULL
+			  AND email_next_attempt_at <= NOW()
+			ORDER BY email_next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		),
+		claimed AS (
+			UPDATE notifications n
+			SET email_attempts = n.email_attempts + 1,
+			    email_next_attempt_at = NOW() + $2::bigint * INTERVAL '1 second'
+			FROM due
+			WHERE n.id = due.id
+			RETURNING n.id, n.user_id, n.type, n.actor_user_id, n.subject_type, n.subject_id, n.payload, n.email_attempts, n.created_at
+		)
+		SELECT c.id::text, c.type, c.subject_type, c.subject_id::text,
+		       COALESCE(pr.title, g.name, ''), c.payload,
+		       CASE WHEN pr.allow_anonymous AND pr.author_id = a.id THEN '' ELSE COALESCE(a.display_name, '') END,
+		       c.email_attempts,
+		       u.email, u.display_name, u.locale, u.email_notifications_enabled AND u.deleted_at IS NULL,
+		       COALESCE(np.email_digest, 'OFF'), c.created_at
+		FROM claimed c
+		INNER JOIN users u ON u.id = c.user_id
+		LEFT JOIN notification_preferences np ON np.user_id = c.user_id
+		LEFT JOIN users a ON a.id = c.actor_user_id AND a.deleted_at IS NULL
+		LEFT JOIN prayer_requests pr ON c.subject_type = 'PRAYER_REQUEST' AND pr.id = c.subject_id
+		LEFT JOIN groups g ON c.subject_type = 'GROUP' AND g.id = c.subject_id
+		ORDER BY c.created_at
+	`, limit, int64(lease/time.Second))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.NotificationEmail, 0)
+	for rows.Next() {
+		var (
+			e            models.NotificationEmail
+			payloadBytes []byte
+		)
+		err = rows.Scan(&e.NotificationID, &e.Type, &e.SubjectType, &e.SubjectID,
+			&e.SubjectTitle, &payloadBytes, &e.ActorName, &e.Attempts,
+			&e.RecipientEmail, &e.RecipientName, &e.Locale, &e.EmailEnabled,
+			&e.Digest, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		e.Payload = map[string]any{}
+		if len(payloadBytes) > 0 {
+			if err = json.Unmarshal(payloadBytes, &e.Payload); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, e)
+	}
+	return items, rows.Err()
+}
+
+func (r *PostgresRepository) MarkNotificationEmailSent(ctx context.Context, notificationID string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notifications
+		SET sent_email_at = NOW(), email_last_error = NULL
+		WHERE id = $1
+	`, notificationID)
+	return err
+}
+
+func (r *PostgresRepository) MarkNotificationEmailSkipped(ctx context.Context, notificationID, reason string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notifications
+		SET email_skipped_at = NOW(), email_last_error = $2
+		WHERE id = $1
+	`, notificationID, reason)
+	return err
+}
+
+func (r *PostgresRepository) RetryNotificationEmail(ctx context.Context, notificationID, lastError string, delay time.Duration) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notifications
+		SET email_last_error = $2,
+		    email_next_attempt_at = NOW() + $3::bigint * INTERVAL '1 second'
+		WHERE id = $1
+	`, notificationID, lastError, int64(delay/time.Second))
+	return err
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"parish-viva/backend/internal/models"
+)
+
+// feedFilterArgs passes empty sets as NULL so they do not filter.
+func feedFilterArgs(viewerID string, f models.FeedFilter) []any {
+	var categories, groupIDs []string
+	for _, c := range f.Categories {
+		categories = append(categories, string(c))
+	}
+	if len(f.GroupIDs) > 0 {
+		groupIDs = f.GroupIDs
+	}
+	return []any{categories, groupIDs, f.AuthorUsername, viewerID, f.CreatedAfter, f.CreatedBefore, f.HasUpdates}
+}
+
+// feedStatusSQL is a plain status predicate the keyset indexes can use.
+func feedStatusSQL(alias string, own bool, f models.FeedFilter) string {
+	if f.AnsweredOnly {
+		return alias + ".status = 'CLOSED' AND " + alias + ".resolution = 'ANSWERED'"
+	}
+	statuses := f.Statuses
+	if len(statuses) == 0 {
+		statuses = []models.PrayerStatus{models.StatusActive}
+		if own {
+			statuses = append(statuses, models.StatusPendingReview)
+		}
+	}
+	var literals []string
+	for _, status := range statuses {
+		if !own && status != models.StatusActive && status != models.StatusClosed {
+			continue
+		}
+		// Only known statuses are written into the query.
+		switch status {
+		case models.StatusActive, models.StatusClosed, models.StatusPendingReview:
+			literals = append(literals, "'"+string(status)+"'")
+		}
+	}
+	switch len(literals) {
+	case 0:
+		return "FALSE"
+	case 1:
+		return alias + ".status = " + literals[0]
+	default:
+		return alias + ".status IN (" + strings.Join(literals, ", ") + ")"
+	}
+}
+
+// feedFilterSQL reads the feedFilterArgs from $first on.
+func feedFilterSQL(alias string, first int, own bool, f models.FeedFilter) string {
+	p := func(i int) string { return fmt.Sprintf("$%d", first+i) }
+	notBlocked := "\n\t\tAND NOT " + blockedBetweenSQL("NULLIF("+p(3)+"::text, '')::uuid", alias+".author_id")
+	if own {
+		notBlocked = ""
+	}
+	return fmt.Sprintf(`(%[9]s)
+		AND (%[2]s::text[] IS NULL OR %[1]s.category::text = ANY(%[2]s::text[]))
+		AND (%[3]s::uuid[] IS NULL OR EXISTS (
+			SELECT 1 FROM prayer_request_groups ff_prg
+			WHERE ff_prg.prayer_request_id = %[1]s.id AND ff_prg.group_id = ANY(%[3]s::uuid[])
+		))
+		AND (NULLIF(%[4]s::text, '') IS NULL OR (
+			%[1]s.author_id = (SELECT id FROM users WHERE username = %[4]s::text AND deleted_at IS NULL)
+			AND (NOT %[1]s.allow_anonymous OR %[1]s.author_id = NULLIF(%[5]s::text, '')::uuid)
+		))
+		AND (%[6]s::timestamptz IS NULL OR %[1]s.created_at >= %[6]s::timestamptz)
+		AND (%[7]s::timestamptz IS NULL OR %[1]s.created_at < %[7]s::timestamptz)
+		AND (%[8]s::bool IS NULL OR EXISTS (
+			SELECT 1 FROM prayer_request_updates ff_pru WHERE ff_pru.prayer_request_id = %[1]s.id
+		) = %[8]s::bool)%[10]s`,
+		alias, p(0), p(1), p(2), p(3), p(4), p(5), p(6), feedStatusSQL(alias, own, f), notBlocked)
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"testing"
+
+	"parish-viva/backend/internal/models"
+)
+
+func TestFeedStatusSQL(t *testing.T) {
+	tests := []struct {
+		name   string
+		own    bool
+		filter models.FeedFilter
+		want   string
+	}{
+		{"default feed", false, models.FeedFilter{}, "pr.status = 'ACTIVE'"},
+		{"default own", true, models.FeedFilter{}, "pr.status IN ('ACTIVE', 'PENDING_REVIEW')"},
+		{"answered", false, models.FeedFilter{AnsweredOnly: true}, "pr.status = 'CLOSED' AND pr.resolution = 'ANSWERED'"},
+		{"answered own", true, models.FeedFilter{AnsweredOnly: true}, "pr.status = 'CLOSED' AND pr.resolution = 'ANSWERED'"},
+		{
+			"statuses",
+			false,
+			models.FeedFilter{Statuses: []models.PrayerStatus{models.StatusActive, models.StatusClosed}},
+			"pr.status IN ('ACTIVE', 'CLOSED')",
+		},
+		{
+			"pending only shows in own branches",
+			false,
+			models.FeedFilter{Statuses: []models.PrayerStatus{models.StatusPendingReview}},
+			"FALSE",
+		},
+		{
+			"pending own",
+			true,
+			models.FeedFilter{Statuses: []models.PrayerStatus{models.StatusPendingReview}},
+			"pr.status = 'PENDING_REVIEW'",
+		},
+		{
+			"unknown statuses are never written",
+			true,
+			models.FeedFilter{Statuses: []models.PrayerStatus{"ACTIVE') OR TRUE --"}},
+			"FALSE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := feedStatusSQL("pr", tt.own, tt.filter); got != tt.want {
+				t.Errorf("feedStatusSQL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
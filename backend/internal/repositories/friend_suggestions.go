@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"parish-viva/backend/internal/models"
+)
+
+func (r *PostgresRepository) ListFriendSuggestions(ctx context.Context, userID string, prayerWindow time.Duration, limit int) ([]models.FriendSuggestion, error) {
+	if limit <= 0 || limit > 30 {
+		limit = 10
+	}
+	rows, err := r.db.Query(ctx, `
+		WITH my_friends AS (
+			SELECT CASE WHEN user_id = $1 THEN friend_user_id ELSE user_id END AS id
+			FROM friendships
+			WHERE status = 'ACCEPTED' AND (user_id = $1 OR friend_user_id = $1)
+		),
+		mutual AS (
+			SELECT CASE WHEN f.user_id = mf.id THEN f.friend_user_id ELSE f.user_id END AS candidate_id,
+			       COUNT(*)::bigint AS n
+			FROM my_friends mf
+			INNER JOIN friendships f
+			        ON f.status = 'ACCEPTED' AND (f.user_id = mf.id OR f.friend_user_id = mf.id)
+			GROUP BY 1
+		),
+		shared AS (
+			SELECT other.user_id AS candidate_id, COUNT(*)::bigint AS n
+			FROM group_memberships mine
+			INNER JOIN groups g ON g.id = mine.group_id AND g.deleted_at IS NULL
+			INNER JOIN group_memberships other
+			        ON other.group_id = mine.group_id AND other.deleted_at IS NULL
+			WHERE mine.user_id = $1 AND mine.deleted_at IS NULL
+			GROUP BY 1
+		),
+		prayed AS (
+			SELECT candidate_id, COUNT(DISTINCT request_id)::bigint AS n
+			FROM (
+				SELECT pr.author_id AS candidate_id, pr.id AS request_id
+				FROM prayer_actions pa
+				INNER JOIN prayer_requests pr ON pr.id = pa.prayer_request_id
+				WHERE pa.user_id = $1
+				  AND pa.created_at >= NOW() - $2::bigint * INTERVAL '1 second'
+				  AND NOT pr.allow_anonymous
+				  AND pr.deleted_at IS NULL
+				UNION ALL
+				SELECT pa.user_id, pr.id
+				FROM prayer_requests pr
+				INNER JOIN prayer_actions pa ON pa.prayer_request_id = pr.id
+				WHERE pr.author_id = $1
+				  AND pa.created_at >= NOW() - $2::bigint * INTERVAL '1 second'
+				  AND NOT pr.allow_anonymous
+				  AND pr.deleted_at IS NULL
+			) interactions
+			GROUP BY 1
+		),
+		candidates AS (
+			SELECT candidate_id FROM mutual
+			UNION SELECT candidate_id FROM shared
+			UNION SELECT candidate_id FROM prayed
+		),
+		scored AS (
+			SELECT u.id, u.username, u.display_name, u.avatar_url,
+			       COALESCE(m.n, 0) AS mutual_friends,
+			       COALESCE(s.n, 0) AS shared_groups,
+			       COALESCE(u.tradition = me.tradition, FALSE) AS same_tradition,
+			       COALESCE(p.n, 0) AS prayed_for
+			FROM candidates c
+			INNER JOIN users u ON u.id = c.candidate_id AND u.deleted_at IS NULL
+			CROSS JOIN (SELECT tradition FROM users WHERE id = $1) me
+			LEFT JOIN mutual m ON m.candidate_id = c.candidate_id
+			LEFT JOIN shared s ON s.candidate_id = c.candidate_id
+			LEFT JOIN prayed p ON p.candidate_id = c.candidate_id
+			LEFT JOIN privacy_settings ps ON ps.user_id = u.id
+			WHERE u.id <> $1
+			  AND NOT EXISTS (
+				  SELECT 1 FROM friendships f
+				  WHERE (f.user_id = $1 AND f.friend_user_id = u.id)
+				     OR (f.user_id = u.id AND f.friend_user_id = $1)
+			  )
+			  AND NOT EXISTS (
+				  SELECT 1 FROM friend_suggestion_dismissals d
+				  WHERE d.user_id = $1 AND d.dismissed_user_id = u.id
+			  )
+			  AND COALESCE(ps.searchable, $4::boolean)
+			  AND CASE COALESCE(ps.friend_requests, $5::text)
+			          WHEN 'EVERYONE' THEN TRUE
+			          WHEN 'FRIENDS_OF_FRIENDS' THEN m.n IS NOT NULL
+			          ELSE FALSE
+			      END
+		)
+		SELECT id::text, username, display_name, avatar_url,
+		       mutual_friends, shared_groups, same_tradition, prayed_for
+		FROM scored
+		ORDER BY mutual_friends * 3 + shared_groups * 2 + prayed_for + CASE WHEN same_tradition THEN 1 ELSE 0 END DESC,
+		         display_name ASC
+		LIMIT $3
+	`, userID, int64(prayerWindow/time.Second), limit,
+		models.DefaultPrivacySettings.Searchable, string(models.DefaultPrivacySettings.FriendRequests))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.FriendSuggestion, 0)
+	for rows.Next() {
+		var item models.FriendSuggestion
+		err = rows.Scan(&item.UserID, &item.Username, &item.DisplayName, &item.AvatarURL,
+			&item.MutualFriends, &item.SharedGroups, &item.SameTradition, &item.RecentPrayers)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (r *PostgresRepository) DismissFriendSuggestion(ctx context.Context, userID, dismissedUserID string) error {
+	ct, err := r.db.Exec(ctx, `
+		INSERT INTO friend_suggestion_dismissals (user_id, dismissed_user_id)
+		SELECT $1, u.id
+		FROM users u
+		WHERE u.id = $2 AND u.id <> $1 AND u.deleted_at IS NULL
+		ON CONFLICT (user_id, dismissed_user_id) DO NOTHING
+	`, userID, dismissedUserID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		// Either already dismissed or no such user.
+		var exists bool
+		err = r.db.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND id <> $2 AND deleted_at IS NULL)
+		`, dismissedUserID, userID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrUserNotFound
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"parish-viva/backend/internal/models"
+)
+
+var ErrFriendNotFound = errors.New("friend not found")
+
+func (r *PostgresRepository) ListOutgoingFriendRequests(ctx context.Context, userID string) ([]models.OutgoingFriendRequest, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT f.id::text, u.id::text, u.username, u.display_name, u.avatar_url, f.created_at
+		FROM friendships f
+		INNER JOIN users u ON u.id = f.friend_user_id
+		WHERE f.user_id = $1
+		  AND f.status = 'PENDING'
+		  AND u.deleted_at IS NULL
+		ORDER BY f.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.OutgoingFriendRequest, 0)
+	for rows.Next() {
+		var item models.OutgoingFriendRequest
+		err = rows.Scan(&item.ID, &item.ToUserID, &item.Username, &item.DisplayName, &item.AvatarURL, &item.RequestedAt)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// RejectFriendRequest drops the request without telling the sender.
+func (r *PostgresRepository) RejectFriendRequest(ctx context.Context, userID, requestID string) error {
+	return r.deleteFriendship(ctx, `
+		DELETE FROM friendships
+		WHERE id = $1 AND friend_user_id = $2 AND status = 'PENDING'
+		RETURNING id::text
+	`, ErrFriendRequestNotFound, requestID, userID)
+}
+
+// CancelFriendRequest withdraws a pending request userID sent.
+func (r *PostgresRepository) CancelFriendRequest(ctx context.Context, userID, requestID string) error {
+	return r.deleteFriendship(ctx, `
+		DELETE FROM friendships
+		WHERE id = $1 AND user_id = $2 AND status = 'PENDING'
+		RETURNING id::text
+	`, ErrFriendRequestNotFound, requestID, userID)
+}
+
+// RemoveFriend works whichever of the two sent the request.
+func (r *PostgresRepository) RemoveFriend(ctx context.Context, userID, friendUserID string) error {
+	return r.deleteFriendship(ctx, `
+		DELETE FROM friendships
+		WHERE status = 'ACCEPTED'
+		  AND ((user_id = $1 AND friend_user_id = $2) OR (user_id = $2 AND friend_user_id = $1))
+		RETURNING id::text
+	`, ErrFriendNotFound, userID, friendUserID)
+}
+
+// deleteFriendship also removes the notifications about the friendship.
+func (r *PostgresRepository) deleteFriendship(ctx context.Context, query string, notFound error, args ...any) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var friendshipID string
+	if err = tx.QueryRow(ctx, query, args...).Scan(&friendshipID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return notFound
+		}
+		return err
+	}
+
+	err = deleteNotificationsWhere(ctx, tx, `subject_type = 'FRIENDSHIP' AND subject_id = $1`, friendshipID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
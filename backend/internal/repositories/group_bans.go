@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"parish-viva/backend/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// isGroupBannedOn reports whether userID has an unexpired ban in groupID.
+func isGroupBannedOn(ctx context.Context, q queryRower, groupID, userID string) (bool, error) {
+	var banned bool
+	err := q.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM bans
+			WHERE group_id = $1
+			  AND user_id = $2
+			  AND (expires_at IS NULL OR expires_at > NOW())
+		)
+	`, groupID, userID).Scan(&banned)
+	return banned, err
+}
+
+func (r *PostgresRepository) IsGroupBanned(ctx context.Context, groupID, userID string) (bool, error) {
+	return isGroupBannedOn(ctx, r.db, groupID, userID)
+}
+
+// BanGroupMember also drops the membership and any join request.
+func (r *PostgresRepository) BanGroupMember(ctx context.Context, in models.CreateGroupBanInput) (models.GroupBan, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.GroupBan{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var ban models.GroupBan
+	err = tx.QueryRow(ctx, `
+		INSERT INTO bans (group_id, user_id, actor_user_id, reason, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (group_id, user_id) DO UPDATE
+		SET actor_user_id = EXCLUDED.actor_user_id,
+			reason = EXCLUDED.reason,
+			expires_at = EXCLUDED.expires_at,
+			created_at = NOW()
+		RETURNING id::text, group_id::text, user_id::text, actor_user_id::text, reason, expires_at, created_at
+	`, in.GroupID, in.UserID, in.ActorUserID, in.Reason, in.ExpiresAt).
+		Scan(&ban.ID, &ban.GroupID, &ban.UserID, &ban.ActorUserID, &ban.Reason, &ban.ExpiresAt, &ban.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return models.GroupBan{}, ErrUserNotFound
+		}
+		return models.GroupBan{}, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE group_memberships
+		SET deleted_at = NOW(), updated_at = NOW()
+		WHERE group_id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`, in.GroupID, in.UserID)
+	if err != nil {
+		return models.GroupBan{}, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE group_join_requests
+		SET status = 'REJECTED', reviewed_at = NOW(), reviewed_by = $3
+		WHERE group_id = $1 AND user_id = $2 AND status = 'PENDING'
+	`, in.GroupID, in.UserID, in.ActorUserID)
+	if err != nil {
+		return models.GroupBan{}, err
+	}
+
+	payload := map[string]any{"banId": ban.ID, "reason": in.Reason}
+	if in.ExpiresAt != nil {
+		payload["expiresAt"] = in.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	targetUserID := in.UserID
+	groupID := in.GroupID
+	_, err = insertModerationActionOn(ctx, tx, models.ModerationAction{
+		ActorUserID:   in.ActorUserID,
+		TargetUserID:  &targetUserID,
+		TargetGroupID: &groupID,
+		Action:        models.ActionBan,
+		Payload:       payload,
+	})
+	if err != nil {
+		return models.GroupBan{}, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		SELECT username, display_name, avatar_url
+		FROM users
+		WHERE id = $1
+	`, in.UserID).Scan(&ban.Username, &ban.DisplayName, &ban.AvatarURL)
+	if err != nil {
+		return models.GroupBan{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.GroupBan{}, err
+	}
+	return ban, nil
+}
+
+// UnbanGroupMember lifts a ban without re-adding the user.
+func (r *PostgresRepository) UnbanGroupMember(ctx context.Context, actorUserID, groupID, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var banID string
+	err = tx.QueryRow(ctx, `
+		DELETE FROM bans
+		WHERE group_id = $1
+		  AND user_id = $2
+		  AND (expires_at IS NULL OR expires_at > NOW())
+		RETURNING id::text
+	`, groupID, userID).Scan(&banID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrGroupBanNotFound
+		}
+		return err
+	}
+
+	targetUserID := userID
+	targetGroupID := groupID
+	_, err = insertModerationActionOn(ctx, tx, models.ModerationAction{
+		ActorUserID:   actorUserID,
+		TargetUserID:  &targetUserID,
+		TargetGroupID: &targetGroupID,
+		Action:        models.ActionUnban,
+		Payload:       map[string]any{"banId": banID},
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresRepository) ListGroupBans(ctx context.Context, groupID string, limit, offset int) ([]models.GroupBan, int64, error) {
+	var total int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)::bigint
+		FROM bans
+		WHERE group_id = $1
+		  AND (expires_at IS NULL OR expires_at > NOW())
+	`, groupID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT b.id::text, b.group_id::text, b.user_id::text,
+		       u.username, u.display_name, u.avatar_url,
+		       b.actor_user_id::text, b.reason, b.expires_at, b.created_at
+		FROM bans b
+		INNER JOIN users u ON u.id = b.user_id
+		WHERE b.group_id = $1
+		  AND (b.expires_at IS NULL OR b.expires_at > NOW())
+		ORDER BY b.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, groupID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]models.GroupBan, 0)
+	for rows.Next() {
+		var ban models.GroupBan
+		err = rows.Scan(&ban.ID, &ban.GroupID, &ban.UserID, &ban.Username, &ban.DisplayName, &ban.AvatarURL, &ban.ActorUserID, &ban.Reason, &ban.ExpiresAt, &ban.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, ban)
+	}
+	return items, total, rows.Err()
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+
+	"parish-viva/backend/internal/models"
+)
+
+// ListHomeFeedCandidates returns the newest home feed requests with their signals.
+func (r *PostgresRepository) ListHomeFeedCandidates(ctx context.Context, userID string, filter models.FeedFilter, limit int) ([]models.FeedCandidate, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH viewer AS (
+			SELECT tradition FROM users WHERE id = $1 AND deleted_at IS NULL
+		),
+		friend_ids AS (
+			SELECT CASE WHEN user_id = $1 THEN friend_user_id ELSE user_id END AS friend_id
+			FROM friendships
+			WHERE status = 'ACCEPTED' AND (user_id = $1 OR friend_user_id = $1)
+		),
+		home_requests AS (
+			SELECT pr.id
+			FROM prayer_requests pr
+			WHERE pr.author_id = $1
+			  AND pr.deleted_at IS NULL
+			  AND `+feedFilterSQL("pr", 3, true, filter)+`
+			UNION
+			SELECT pr.id
+			FROM prayer_requests pr
+			INNER JOIN friend_ids f ON f.friend_id = pr.author_id
+			WHERE pr.visibility = 'PUBLIC' AND pr.deleted_at IS NULL
+			  AND pr.tradition = (SELECT tradition FROM viewer)
+			  AND `+feedFilterSQL("pr", 3, false, filter)+`
+			UNION
+			SELECT pr.id
+			FROM prayer_requests pr
+			INNER JOIN prayer_request_groups prg ON prg.prayer_request_id = pr.id
+			INNER JOIN group_memberships gm ON gm.group_id = prg.group_id
+			WHERE gm.user_id = $1 AND gm.deleted_at IS NULL AND pr.deleted_at IS NULL
+			  AND pr.tradition = (SELECT tradition FROM viewer)
+			  AND `+feedFilterSQL("pr", 3, false, filter)+`
+		)
+		SELECT pr.id::text, pr.created_at, pr.prayed_count,
+			CASE
+				WHEN pr.author_id = $1 THEN 'OWN'
+				WHEN pr.author_id IN (SELECT friend_id FROM friend_ids) THEN 'FRIEND'
+				ELSE 'GROUP'
+			END,
+			EXISTS (
+				SELECT 1 FROM prayer_actions pa
+				WHERE pa.prayer_request_id = pr.id AND pa.user_id = $1
+			)
+		FROM home_requests h
+		INNER JOIN prayer_requests pr ON pr.id = h.id
+		ORDER BY pr.created_at DESC, pr.id DESC
+		LIMIT $2
+	`, append([]any{userID, limit}, feedFilterArgs(userID, filter)...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.FeedCandidate, 0)
+	for rows.Next() {
+		var c models.FeedCandidate
+		if err = rows.Scan(&c.RequestID, &c.CreatedAt, &c.PrayedCount, &c.Relation, &c.ViewerPrayed); err != nil {
+			return nil, err
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
+
+// ListPrayerRequestsByIDs does not check visibility.
+func (r *PostgresRepository) ListPrayerRequestsByIDs(ctx context.Context, userID string, ids []string) ([]models.PrayerRequest, error) {
+	if len(ids) == 0 {
+		return []models.PrayerRequest{}, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT pr.id::text, pr.author_id::text, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.comments_enabled, pr.comment_count, pr.resolution, pr.testimony, pr.closed_at, pr.created_at, pr.updated_at
+		FROM unnest($1::uuid[]) WITH ORDINALITY AS wanted(id, position)
+		INNER JOIN prayer_requests pr ON pr.id = wanted.id
+		WHERE pr.deleted_at IS NULL
+		ORDER BY wanted.position
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items, err := scanPrayerRequests(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.enrichPrayerRequests(ctx, userID, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
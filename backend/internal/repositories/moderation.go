@@ -0,0 +1,839 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"parish-viva/backend/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (r *PostgresRepository) GetPlatformRole(ctx context.Context, userID string) (models.PlatformRole, error) {
+	var role string
+	err := r.db.QueryRow(ctx, `
+		SELECT platform_role
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+	`, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PlatformRoleUser, nil
+		}
+		return "", err
+	}
+	return models.PlatformRole(role), nil
+}
+
+func (r *PostgresRepository) ListModeratedGroupIDs(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT gm.group_id::text
+		FROM group_memberships gm
+		INNER JOIN groups g ON g.id = gm.group_id
+		WHERE gm.user_id = $1
+		  AND gm.role IN ('MODERATOR', 'ADMIN')
+		  AND gm.deleted_at IS NULL
+		  AND g.deleted_at IS NULL
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *PostgresRepository) EnqueueModerationItem(ctx context.Context, in models.EnqueueModerationInput) error {
+	return enqueueModerationOn(ctx, r.db, in)
+}
+
+// enqueueModerationOn bumps the open item for the pair instead of duplicating it.
+func enqueueModerationOn(ctx context.Context, exec notifyExec, in models.EnqueueModerationInput) error {
+	_, err := exec.Exec(ctx, `
+		INSERT INTO moderation_queue (prayer_request_id, group_id, reason, status)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, 'PENDING')
+		ON CONFLICT (prayer_request_id, COALESCE(group_id, '00000000-0000-0000-0000-000000000000'::uuid))
+			WHERE status <> 'RESOLVED'
+		DO UPDATE SET
+			reason = CASE WHEN moderation_queue.reason = 'REPORTED' THEN moderation_queue.reason ELSE EXCLUDED.reason END,
+			updated_at = NOW()
+	`, in.PrayerRequestID, nullableStringValue(in.GroupID), string(in.Reason))
+	return err
+}
+
+func (r *PostgresRepository) ListModerationQueue(ctx context.Context, filter models.ModerationQueueFilter) ([]models.ModerationQueueItem, int64, error) {
+	statuses := make([]string, 0, len(filter.Statuses))
+	for _, s := range filter.Statuses {
+		statuses = append(statuses, string(s))
+	}
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	groupIDs := filter.GroupIDs
+	if groupIDs == nil {
+		groupIDs = []string{}
+	}
+
+	var total int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)::bigint
+		FROM moderation_queue mq
+		LEFT JOIN prayer_requests pr ON pr.id = mq.prayer_request_id
+		WHERE (mq.prayer_request_id IS NULL OR pr.deleted_at IS NULL)
+		  AND ($1::text[] IS NULL OR mq.status = ANY($1::text[]))
+		  AND (NULLIF($2, '') IS NULL OR mq.group_id = NULLIF($2, '')::uuid)
+		  AND (NULLIF($3, '') IS NULL OR mq.reason = $3)
+		  AND ($4::bool OR mq.group_id = ANY($5::uuid[]))
+	`, statuses, filter.GroupID, string(filter.Reason), filter.PlatformScope, groupIDs).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT mq.id::text, mq.prayer_request_id::text, mq.comment_id::text, mq.target_user_id::text, mq.group_id::text, g.name, mq.reason, mq.status,
+		       mq.report_count, mq.claimed_by::text, mq.claimed_at, mq.created_at, mq.updated_at, mq.resolved_at,
+		       tu.username, tu.display_name, tu.avatar_url
+		FROM moderation_queue mq
+		LEFT JOIN prayer_requests pr ON pr.id = mq.prayer_request_id
+		LEFT JOIN users tu ON tu.id = mq.target_user_id
+		LEFT JOIN groups g ON g.id = mq.group_id
+		WHERE (mq.prayer_request_id IS NULL OR pr.deleted_at IS NULL)
+		  AND ($1::text[] IS NULL OR mq.status = ANY($1::text[]))
+		  AND (NULLIF($2, '') IS NULL OR mq.group_id = NULLIF($2, '')::uuid)
+		  AND (NULLIF($3, '') IS NULL OR mq.reason = $3)
+		  AND ($4::bool OR mq.group_id = ANY($5::uuid[]))
+		ORDER BY mq.created_at ASC
+		LIMIT $6 OFFSET $7
+	`, statuses, filter.GroupID, string(filter.Reason), filter.PlatformScope, groupIDs, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]models.ModerationQueueItem, 0)
+	for rows.Next() {
+		item, err := scanModerationQueueItem(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if err = r.enrichModerationQueueItems(ctx, items); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *PostgresRepository) GetModerationQueueItem(ctx context.Context, itemID string) (models.ModerationQueueItem, error) {
+	row := r.db.QueryRow(ctx, `
+		SELECT mq.id::text, mq.prayer_request_id::text, mq.comment_id::text, mq.target_user_id::text, mq.group_id::text, g.name, mq.reason, mq.status,
+		       mq.report_count, mq.claimed_by::text, mq.claimed_at, mq.created_at, mq.updated_at, mq.resolved_at,
+		       tu.username, tu.display_name, tu.avatar_url
+		FROM moderation_queue mq
+		LEFT JOIN prayer_requests pr ON pr.id = mq.prayer_request_id
+		LEFT JOIN users tu ON tu.id = mq.target_user_id
+		LEFT JOIN groups g ON g.id = mq.group_id
+		WHERE mq.id = $1
+	`, itemID)
+	item, err := scanModerationQueueItem(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ModerationQueueItem{}, ErrModerationItemNotFound
+		}
+		return models.ModerationQueueItem{}, err
+	}
+	items := []models.ModerationQueueItem{item}
+	if err = r.enrichModerationQueueItems(ctx, items); err != nil {
+		return models.ModerationQueueItem{}, err
+	}
+	return items[0], nil
+}
+
+func (r *PostgresRepository) ClaimModerationQueueItem(ctx context.Context, itemID, moderatorID string) (models.ModerationQueueItem, error) {
+	// A claim older than 30 minutes is considered abandoned and can be taken over.
+	ct, err := r.db.Exec(ctx, `
+		UPDATE moderation_queue
+		SET status = 'IN_REVIEW', claimed_by = $2, claimed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		  AND (
+			status = 'PENDING'
+			OR (status = 'IN_REVIEW' AND (claimed_by = $2 OR claimed_at < NOW() - INTERVAL '30 minutes'))
+		  )
+	`, itemID, moderatorID)
+	if err != nil {
+		return models.ModerationQueueItem{}, err
+	}
+	item, err := r.GetModerationQueueItem(ctx, itemID)
+	if err != nil {
+		return models.ModerationQueueItem{}, err
+	}
+	if ct.RowsAffected() == 0 {
+		if item.Status == models.QueueStatusResolved {
+			return models.ModerationQueueItem{}, ErrModerationItemResolved
+		}
+		return models.ModerationQueueItem{}, ErrModerationItemClaimed
+	}
+	return item, nil
+}
+
+func scanModerationQueueItem(row pgx.Row) (models.ModerationQueueItem, error) {
+	var (
+		item              models.ModerationQueueItem
+		targetUsername    *string
+		targetDisplayName *string
+		targetAvatarURL   *string
+	)
+	err := row.Scan(&item.ID, &item.PrayerRequestID, &item.CommentID, &item.TargetUserID, &item.GroupID, &item.GroupName, &item.Reason, &item.Status,
+		&item.ReportCount, &item.ClaimedBy, &item.ClaimedAt, &item.CreatedAt, &item.UpdatedAt, &item.ResolvedAt,
+		&targetUsername, &targetDisplayName, &targetAvatarURL)
+	if err != nil {
+		return models.ModerationQueueItem{}, err
+	}
+	if item.TargetUserID != nil {
+		item.TargetUser = &models.ModerationTargetUser{
+			UserID:      *item.TargetUserID,
+			Username:    derefStr(targetUsername),
+			DisplayName: derefStr(targetDisplayName),
+			AvatarURL:   targetAvatarURL,
+		}
+	}
+	return item, nil
+}
+
+func (r *PostgresRepository) enrichModerationQueueItems(ctx context.Context, items []models.ModerationQueueItem) error {
+	commentIDs := make([]string, 0)
+	for _, item := range items {
+		if item.CommentID != nil {
+			commentIDs = append(commentIDs, *item.CommentID)
+		}
+	}
+	commentRequestIDs := make(map[string]string, len(commentIDs))
+	if len(commentIDs) > 0 {
+		comments, err := r.loadComments(ctx, commentIDs)
+		if err != nil {
+			return err
+		}
+		commentByID := make(map[string]models.Comment, len(comments))
+		for _, c := range comments {
+			commentByID[c.ID] = c
+			commentRequestIDs[c.ID] = c.PrayerRequestID
+		}
+		for i := range items {
+			if items[i].CommentID == nil {
+				continue
+			}
+			if c, ok := commentByID[*items[i].CommentID]; ok {
+				items[i].Comment = &c
+			}
+		}
+	}
+
+	requestIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.PrayerRequestID != nil {
+			requestIDs = append(requestIDs, *item.PrayerRequestID)
+		}
+	}
+	for _, requestID := range commentRequestIDs {
+		requestIDs = append(requestIDs, requestID)
+	}
+	if len(requestIDs) == 0 {
+		return nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, comments_enabled, comment_count, resolution, testimony, closed_at, created_at, updated_at
+		FROM prayer_requests
+		WHERE id::text = ANY($1::text[])
+	`, requestIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	requests, err := scanPrayerRequests(rows)
+	if err != nil {
+		return err
+	}
+	if err = r.enrichPrayerRequests(ctx, "", requests); err != nil {
+		return err
+	}
+	byID := make(map[string]models.PrayerRequest, len(requests))
+	for _, pr := range requests {
+		byID[pr.ID] = pr
+	}
+	for i := range items {
+		requestID := ""
+		if items[i].PrayerRequestID != nil {
+			requestID = *items[i].PrayerRequestID
+		} else if items[i].CommentID != nil {
+			requestID = commentRequestIDs[*items[i].CommentID]
+		}
+		if pr, ok := byID[requestID]; ok {
+			items[i].Request = &pr
+		}
+	}
+	return nil
+}
+
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// GetPrayerRequestForModeration ignores visibility.
+func (r *PostgresRepository) GetPrayerRequestForModeration(ctx context.Context, requestID string) (models.PrayerRequest, error) {
+	var pr models.PrayerRequest
+	err := r.db.QueryRow(ctx, `
+		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, comments_enabled, comment_count, resolution, testimony, closed_at, created_at, updated_at
+		FROM prayer_requests
+		WHERE id = $1 AND deleted_at IS NULL
+	`, requestID).Scan(&pr.ID, &pr.AuthorID, &pr.Title, &pr.Body, &pr.Category, &pr.Visibility, &pr.Tradition, &pr.AllowAnonymous, &pr.Status, &pr.PrayedCount, &pr.CommentsEnabled, &pr.CommentCount, &pr.Resolution, &pr.Testimony, &pr.ClosedAt, &pr.CreatedAt, &pr.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PrayerRequest{}, ErrPrayerRequestNotFound
+		}
+		return models.PrayerRequest{}, err
+	}
+	items := []models.PrayerRequest{pr}
+	if err = r.enrichPrayerRequests(ctx, "", items); err != nil {
+		return models.PrayerRequest{}, err
+	}
+	return items[0], nil
+}
+
+func (r *PostgresRepository) ApplyModerationDecision(ctx context.Context, d models.ModerationDecision) (models.ModerationAction, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if d.QueueItemID != "" {
+		var (
+			status     models.ModerationQueueStatus
+			claimedBy  *string
+			claimStale bool
+		)
+		err = tx.QueryRow(ctx, `
+			SELECT status, claimed_by::text, COALESCE(claimed_at < NOW() - INTERVAL '30 minutes', TRUE)
+			FROM moderation_queue
+			WHERE id = $1 AND prayer_request_id = $2
+			FOR UPDATE
+		`, d.QueueItemID, d.RequestID).Scan(&status, &claimedBy, &claimStale)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.ModerationAction{}, ErrModerationItemNotFound
+			}
+			return models.ModerationAction{}, err
+		}
+		if status == models.QueueStatusResolved {
+			return models.ModerationAction{}, ErrModerationItemResolved
+		}
+		if claimedBy != nil && *claimedBy != d.ActorUserID && !claimStale {
+			return models.ModerationAction{}, ErrModerationItemClaimed
+		}
+	}
+
+	var (
+		authorID   string
+		fromStatus models.PrayerStatus
+		visibility models.Visibility
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT author_id::text, status, visibility
+		FROM prayer_requests
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`, d.RequestID).Scan(&authorID, &fromStatus, &visibility)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ModerationAction{}, ErrPrayerRequestNotFound
+		}
+		return models.ModerationAction{}, err
+	}
+
+	var resolved pgx.Rows
+	if d.GroupOnly {
+		resolved, err = tx.Query(ctx, `
+			UPDATE moderation_queue
+			SET status = 'RESOLVED', resolved_at = NOW(), resolved_by = $3, updated_at = NOW()
+			WHERE prayer_request_id = $1 AND group_id = $2 AND status <> 'RESOLVED'
+			RETURNING id::text
+		`, d.RequestID, d.GroupID, d.ActorUserID)
+	} else if d.ResolveAll || d.QueueItemID == "" {
+		resolved, err = tx.Query(ctx, `
+			UPDATE moderation_queue
+			SET status = 'RESOLVED', resolved_at = NOW(), resolved_by = $2, updated_at = NOW()
+			WHERE prayer_request_id = $1 AND status <> 'RESOLVED'
+			RETURNING id::text
+		`, d.RequestID, d.ActorUserID)
+	} else {
+		resolved, err = tx.Query(ctx, `
+			UPDATE moderation_queue
+			SET status = 'RESOLVED', resolved_at = NOW(), resolved_by = $2, updated_at = NOW()
+			WHERE id = $1
+			RETURNING id::text
+		`, d.QueueItemID, d.ActorUserID)
+	}
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	resolvedIDs, err := scanIDs(resolved)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+
+	toStatus := d.ToStatus
+	if d.GroupOnly || (d.FromStatus != "" && fromStatus != d.FromStatus) {
+		toStatus = fromStatus
+	}
+	if d.GroupOnly {
+		_, err = tx.Exec(ctx, `
+			DELETE FROM prayer_request_groups WHERE prayer_request_id = $1 AND group_id = $2
+		`, d.RequestID, d.GroupID)
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+		if toStatus, err = statusAfterGroupRemoval(ctx, tx, d.RequestID, fromStatus, visibility); err != nil {
+			return models.ModerationAction{}, err
+		}
+	}
+	if d.WhenQueueClear && toStatus != fromStatus {
+		var stillOpen bool
+		err = tx.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM moderation_queue
+				WHERE prayer_request_id = $1 AND status <> 'RESOLVED'
+			)
+		`, d.RequestID).Scan(&stillOpen)
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+		if stillOpen {
+			toStatus = fromStatus
+		}
+	}
+	if toStatus != fromStatus {
+		_, err = tx.Exec(ctx, `
+			UPDATE prayer_requests
+			SET status = $2, updated_at = NOW()
+			WHERE id = $1
+		`, d.RequestID, toStatus)
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+	}
+
+	payload := map[string]any{
+		"previousStatus": string(fromStatus),
+		"newStatus":      string(toStatus),
+	}
+	if d.QueueItemID != "" {
+		payload["queueItemId"] = d.QueueItemID
+	}
+	if d.GroupOnly {
+		payload["removedFromGroup"] = *d.GroupID
+	}
+	if d.Reason != "" {
+		payload["reason"] = d.Reason
+	}
+	if d.Note != "" {
+		payload["note"] = d.Note
+	}
+	author := authorID
+	requestID := d.RequestID
+	action, err := insertModerationActionOn(ctx, tx, models.ModerationAction{
+		ActorUserID:     d.ActorUserID,
+		TargetUserID:    &author,
+		TargetRequestID: &requestID,
+		TargetGroupID:   d.GroupID,
+		Action:          d.Action,
+		Payload:         payload,
+	})
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+
+	if err = resolveReportsOn(ctx, tx, resolvedIDs, d.ActorUserID, d.Action); err != nil {
+		return models.ModerationAction{}, err
+	}
+
+	if authorID != d.ActorUserID {
+		notifyPayload := map[string]any{
+			"action": string(d.Action),
+			"status": string(toStatus),
+		}
+		if d.Reason != "" {
+			notifyPayload["reason"] = d.Reason
+		}
+		if d.Note != "" {
+			notifyPayload["note"] = d.Note
+		}
+		if d.GroupOnly {
+			notifyPayload["groupId"] = *d.GroupID
+		}
+		actor := d.ActorUserID
+		_ = insertNotificationInTx(ctx, tx, models.CreateNotificationInput{
+			UserID:      authorID,
+			Type:        models.NotificationTypeRequestModerated,
+			ActorUserID: &actor,
+			SubjectType: models.NotificationSubjectPrayerRequest,
+			SubjectID:   d.RequestID,
+			Payload:     notifyPayload,
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.ModerationAction{}, err
+	}
+	return action, nil
+}
+
+// statusAfterGroupRemoval settles a request no group is reviewing.
+func statusAfterGroupRemoval(ctx context.Context, tx pgx.Tx, requestID string, fromStatus models.PrayerStatus, visibility models.Visibility) (models.PrayerStatus, error) {
+	if fromStatus != models.StatusPendingReview && fromStatus != models.StatusActive {
+		return fromStatus, nil
+	}
+	var hasGroups, stillOpen bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM prayer_request_groups WHERE prayer_request_id = $1),
+		       EXISTS (SELECT 1 FROM moderation_queue WHERE prayer_request_id = $1 AND status <> 'RESOLVED')
+	`, requestID).Scan(&hasGroups, &stillOpen)
+	switch {
+	case err != nil:
+		return "", err
+	case stillOpen:
+		return fromStatus, nil
+	case hasGroups || visibility == models.VisibilityPublic:
+		return models.StatusActive, nil
+	default:
+		return models.StatusRemoved, nil
+	}
+}
+
+func (r *PostgresRepository) GetModerationAction(ctx context.Context, actionID string) (models.ModerationAction, error) {
+	action, err := scanModerationAction(r.db.QueryRow(ctx, `
+		SELECT ma.id::text, ma.actor_user_id::text, u.username, ma.target_user_id::text, ma.target_request_id::text,
+		       ma.target_group_id::text, ma.action, ma.payload, ma.created_at
+		FROM moderation_actions ma
+		LEFT JOIN users u ON u.id = ma.actor_user_id
+		WHERE ma.id = $1
+	`, actionID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ModerationAction{}, ErrModerationActionNotFound
+		}
+		return models.ModerationAction{}, err
+	}
+	return action, nil
+}
+
+// RevertModerationAction records a RESTORE pointing at the action.
+func (r *PostgresRepository) RevertModerationAction(ctx context.Context, actorUserID, actionID string) (models.ModerationAction, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	original, err := scanModerationAction(tx.QueryRow(ctx, `
+		SELECT ma.id::text, ma.actor_user_id::text, '', ma.target_user_id::text, ma.target_request_id::text,
+		       ma.target_group_id::text, ma.action, ma.payload, ma.created_at
+		FROM moderation_actions ma
+		WHERE ma.id = $1
+		FOR UPDATE
+	`, actionID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ModerationAction{}, ErrModerationActionNotFound
+		}
+		return models.ModerationAction{}, err
+	}
+	previousStatus, _ := original.Payload["previousStatus"].(string)
+	newStatus, _ := original.Payload["newStatus"].(string)
+	removedFromGroup, _ := original.Payload["removedFromGroup"].(string)
+	if original.Action == models.ActionRestore || original.TargetRequestID == nil || previousStatus == "" ||
+		(previousStatus == newStatus && removedFromGroup == "") {
+		return models.ModerationAction{}, ErrModerationActionNotRevertible
+	}
+
+	var (
+		authorID      string
+		currentStatus string
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT author_id::text, status
+		FROM prayer_requests
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`, *original.TargetRequestID).Scan(&authorID, &currentStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ModerationAction{}, ErrPrayerRequestNotFound
+		}
+		return models.ModerationAction{}, err
+	}
+	if currentStatus != newStatus {
+		return models.ModerationAction{}, ErrModerationStateChanged
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE prayer_requests
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1
+	`, *original.TargetRequestID, previousStatus)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	if removedFromGroup != "" {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO prayer_request_groups (prayer_request_id, group_id)
+			SELECT $1, g.id FROM groups g WHERE g.id = $2 AND g.deleted_at IS NULL
+			ON CONFLICT DO NOTHING
+		`, *original.TargetRequestID, removedFromGroup)
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+	}
+	if previousStatus != newStatus && models.PrayerStatus(previousStatus) == models.StatusPendingReview {
+		if err = enqueueModerationOn(ctx, tx, models.EnqueueModerationInput{
+			PrayerRequestID: *original.TargetRequestID,
+			GroupID:         original.TargetGroupID,
+			Reason:          models.ReasonReverted,
+		}); err != nil {
+			return models.ModerationAction{}, err
+		}
+	}
+
+	author := authorID
+	action, err := insertModerationActionOn(ctx, tx, models.ModerationAction{
+		ActorUserID:     actorUserID,
+		TargetUserID:    &author,
+		TargetRequestID: original.TargetRequestID,
+		TargetGroupID:   original.TargetGroupID,
+		Action:          models.ActionRestore,
+		Payload: map[string]any{
+			"revertsActionId": original.ID,
+			"previousStatus":  newStatus,
+			"newStatus":       previousStatus,
+		},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return models.ModerationAction{}, ErrModerationActionReverted
+		}
+		return models.ModerationAction{}, err
+	}
+
+	if authorID != actorUserID {
+		actor := actorUserID
+		_ = insertNotificationInTx(ctx, tx, models.CreateNotificationInput{
+			UserID:      authorID,
+			Type:        models.NotificationTypeRequestModerated,
+			ActorUserID: &actor,
+			SubjectType: models.NotificationSubjectPrayerRequest,
+			SubjectID:   *original.TargetRequestID,
+			Payload: map[string]any{
+				"action": string(models.ActionRestore),
+				"status": previousStatus,
+			},
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.ModerationAction{}, err
+	}
+	return action, nil
+}
+
+func (r *PostgresRepository) ListModerationActions(ctx context.Context, requestID string) ([]models.ModerationAction, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT ma.id::text, ma.actor_user_id::text, u.username, ma.target_user_id::text, ma.target_request_id::text,
+		       ma.target_group_id::text, ma.action, ma.payload, ma.created_at
+		FROM moderation_actions ma
+		LEFT JOIN users u ON u.id = ma.actor_user_id
+		WHERE ma.target_request_id = $1
+		ORDER BY ma.created_at DESC
+	`, requestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.ModerationAction, 0)
+	for rows.Next() {
+		action, err := scanModerationAction(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, action)
+	}
+	return items, rows.Err()
+}
+
+func insertModerationActionOn(ctx context.Context, q queryRower, in models.ModerationAction) (models.ModerationAction, error) {
+	payload := in.Payload
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	out := in
+	out.Payload = payload
+	err = q.QueryRow(ctx, `
+		INSERT INTO moderation_actions (actor_user_id, target_user_id, target_request_id, target_group_id, action, payload)
+		VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5, $6::jsonb)
+		RETURNING id::text, created_at
+	`, in.ActorUserID, nullableStringValue(in.TargetUserID), nullableStringValue(in.TargetRequestID), nullableStringValue(in.TargetGroupID), string(in.Action), string(payloadBytes)).
+		Scan(&out.ID, &out.CreatedAt)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	return out, nil
+}
+
+func scanModerationAction(row pgx.Row) (models.ModerationAction, error) {
+	var (
+		a             models.ModerationAction
+		actorUsername *string
+		payloadBytes  []byte
+	)
+	err := row.Scan(&a.ID, &a.ActorUserID, &actorUsername, &a.TargetUserID, &a.TargetRequestID, &a.TargetGroupID, &a.Action, &payloadBytes, &a.CreatedAt)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	a.ActorUsername = derefStr(actorUsername)
+	if len(payloadBytes) > 0 {
+		if err = json.Unmarshal(payloadBytes, &a.Payload); err != nil {
+			return models.ModerationAction{}, err
+		}
+	}
+	if a.Payload == nil {
+		a.Payload = map[string]any{}
+	}
+	return a, nil
+}
+
+func (r *PostgresRepository) FilterGroupsRequiringModeration(ctx context.Context, groupIDs []string) ([]string, error) {
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT id::text
+		FROM groups
+		WHERE id::text = ANY($1::text[])
+		  AND requires_moderation = TRUE
+		  AND deleted_at IS NULL
+	`, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
+
+// attachReviewState tells authors why a request is PENDING_REVIEW.
+func (r *PostgresRepository) attachReviewState(ctx context.Context, userID string, items []models.PrayerRequest) error {
+	byID := make(map[string]int)
+	ids := make([]string, 0)
+	for i := range items {
+		if items[i].Status != models.StatusPendingReview || items[i].AuthorID != userID {
+			continue
+		}
+		byID[items[i].ID] = i
+		ids = append(ids, items[i].ID)
+		items[i].Review = &models.PrayerReview{PendingGroupNames: []string{}}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT mq.prayer_request_id::text, g.name
+		FROM moderation_queue mq
+		LEFT JOIN groups g ON g.id = mq.group_id
+		WHERE mq.prayer_request_id::text = ANY($1::text[])
+		  AND mq.status <> 'RESOLVED'
+		ORDER BY g.name NULLS FIRST
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	open := make(map[string]bool)
+	for rows.Next() {
+		var requestID string
+		var groupName *string
+		if err = rows.Scan(&requestID, &groupName); err != nil {
+			return err
+		}
+		open[requestID] = true
+		review := items[byID[requestID]].Review
+		if groupName == nil {
+			review.PlatformReview = true
+			continue
+		}
+		review.PendingGroupNames = append(review.PendingGroupNames, *groupName)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	actionRows, err := r.db.Query(ctx, `
+		SELECT DISTINCT ON (target_request_id)
+			target_request_id::text, action::text, payload->>'reason', payload->>'note'
+		FROM moderation_actions
+		WHERE target_request_id::text = ANY($1::text[])
+		  AND NOT payload ? 'commentId'
+		ORDER BY target_request_id, created_at DESC
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer actionRows.Close()
+	for actionRows.Next() {
+		var requestID string
+		var action models.ModerationActionType
+		var reason, note *string
+		if err = actionRows.Scan(&requestID, &action, &reason, &note); err != nil {
+			return err
+		}
+		if open[requestID] || action != models.ActionRequestChanges {
+			continue
+		}
+		review := items[byID[requestID]].Review
+		review.ChangesRequested = true
+		review.Reason = nilIfEmpty(reason)
+		review.Note = nilIfEmpty(note)
+	}
+	return actionRows.Err()
+}
+
+func nilIfEmpty(value *string) *string {
+	if value == nil || *value == "" {
+		return nil
+	}
+	return value
+}
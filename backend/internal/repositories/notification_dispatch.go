@@ -0,0 +1,301 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"parish-viva/backend/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type notifyExec interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// quietHoursLayout is how quiet hours are written in preferences.
+const quietHoursLayout = "15:04"
+
+const (
+	// prayedGroupWindow is how long an unread PRAYED row absorbs prayers.
+	prayedGroupWindow = 24 * time.Hour
+	// prayedActorSample is how many actors a grouped PRAYED row names.
+	prayedActorSample = 3
+)
+
+type recipientChannels struct {
+	channels     models.NotificationChannels
+	muted        bool
+	blocked      bool
+	emailEnabled bool
+	digest       models.DigestFrequency
+	timezone     string
+	quietStart   *string
+	quietEnd     *string
+	pushTargets  bool
+}
+
+// dispatchNotification stores a notification on the channels the recipient wants.
+func dispatchNotification(ctx context.Context, exec notifyExec, in models.CreateNotificationInput) error {
+	rc, err := loadRecipientChannels(ctx, exec, in)
+	if err != nil {
+		log.Printf("notification dispatch failed: preferences type=%s user=%s err=%v", in.Type, in.UserID, err)
+		return err
+	}
+	if rc.muted || rc.blocked {
+		return nil
+	}
+
+	var emailSkipReason string
+	switch {
+	case !rc.channels.Email:
+		emailSkipReason = "email off for type"
+	case !rc.emailEnabled:
+		emailSkipReason = "email disabled"
+	case rc.digest != models.DigestOff:
+		emailSkipReason = "sent in digest"
+	}
+	var pushSkipReason string
+	switch {
+	case !rc.channels.Push:
+		pushSkipReason = "push off for type"
+	case !rc.pushTargets:
+		pushSkipReason = "no push subscriptions"
+	}
+	if !rc.channels.InApp && emailSkipReason != "" && pushSkipReason != "" {
+		return nil
+	}
+	if rc.channels.InApp && in.Type == models.NotificationTypePrayed {
+		merged, err := mergePrayedNotification(ctx, exec, in)
+		if err != nil || merged {
+			return err
+		}
+	}
+
+	var quietUntil *time.Time
+	if rc.quietStart != nil && rc.quietEnd != nil {
+		if end, ok := quietHoursEnd(time.Now(), rc.timezone, *rc.quietStart, *rc.quietEnd); ok {
+			quietUntil = &end
+		}
+	}
+	return insertNotificationOn(ctx, exec, in, deliveryPlan{
+		inApp:           rc.channels.InApp,
+		emailSkipReason: emailSkipReason,
+		pushSkipReason:  pushSkipReason,
+		quietUntil:      quietUntil,
+	})
+}
+
+func loadRecipientChannels(ctx context.Context, exec notifyExec, in models.CreateNotificationInput) (recipientChannels, error) {
+	var (
+		rc            recipientChannels
+		channelsBytes []byte
+	)
+	err := exec.QueryRow(ctx, `
+		SELECT u.email_notifications_enabled, COALESCE(np.email_digest, 'OFF'), COALESCE(np.timezone, $2),
+		       np.type_channels -> $3::text, to_char(np.quiet_hours_start, 'HH24:MI'), to_char(np.quiet_hours_end, 'HH24:MI'),
+		       CASE $4::text
+		           WHEN 'GROUP' THEN EXISTS (
+		               SELECT 1 FROM notification_group_mutes m
+		               WHERE m.user_id = u.id AND m.group_id = $5::uuid
+		           )
+		           WHEN 'PRAYER_REQUEST' THEN EXISTS (
+		               SELECT 1 FROM prayer_requests pr
+		               WHERE pr.id = $5::uuid
+		                 AND pr.visibility = 'GROUP_ONLY'
+		                 AND pr.author_id <> u.id
+		                 AND EXISTS (SELECT 1 FROM prayer_request_groups prg WHERE prg.prayer_request_id = pr.id)
+		                 AND NOT EXISTS (
+		                     SELECT 1 FROM prayer_request_groups prg
+		                     WHERE prg.prayer_request_id = pr.id
+		                       AND NOT EXISTS (
+		                           SELECT 1 FROM notification_group_mutes m
+		                           WHERE m.user_id = u.id AND m.group_id = prg.group_id
+		                       )
+		                 )
+		           )
+		           ELSE FALSE
+		       END,
+		       EXISTS (SELECT 1 FROM push_subscriptions ps WHERE ps.user_id = u.id),
+		       `+blockedBetweenSQL("u.id", "$6::uuid")+`
+		FROM users u
+		LEFT JOIN notification_preferences np ON np.user_id = u.id
+		WHERE u.id = $1::uuid AND u.deleted_at IS NULL
+	`, in.UserID, models.DefaultTimezone, string(in.Type), string(in.SubjectType), in.SubjectID, in.ActorUserID).Scan(
+		&rc.emailEnabled, &rc.digest, &rc.timezone, &channelsBytes, &rc.quietStart, &rc.quietEnd, &rc.muted, &rc.pushTargets, &rc.blocked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return recipientChannels{}, ErrUserNotFound
+	}
+	if err != nil {
+		return recipientChannels{}, err
+	}
+	rc.channels = models.DefaultNotificationChannels
+	if len(channelsBytes) > 0 {
+		if err = json.Unmarshal(channelsBytes, &rc.channels); err != nil {
+			return recipientChannels{}, err
+		}
+	}
+	return rc, nil
+}
+
+// quietHoursEnd reports when the quiet window around now ends.
+func quietHoursEnd(now time.Time, timezone, start, end string) (time.Time, bool) {
+	s, err := time.Parse(quietHoursLayout, start)
+	if err != nil {
+		return time.Time{}, false
+	}
+	e, err := time.Parse(quietHoursLayout, end)
+	if err != nil {
+		return time.Time{}, false
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := s.Hour()*60 + s.Minute()
+	endMinute := e.Hour()*60 + e.Minute()
+
+	var inside bool
+	if startMinute < endMinute {
+		inside = minute >= startMinute && minute < endMinute
+	} else {
+		inside = minute >= startMinute || minute < endMinute
+	}
+	if !inside {
+		return time.Time{}, false
+	}
+	until := time.Date(local.Year(), local.Month(), local.Day(), e.Hour(), e.Minute(), 0, 0, loc)
+	if endMinute <= minute {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until, true
+}
+
+// mergePrayedNotification reports false when there is no unread row to fold into.
+func mergePrayedNotification(ctx context.Context, exec notifyExec, in models.CreateNotificationInput) (bool, error) {
+	actionType, _ := in.Payload["actionType"].(string)
+	actorID := nullableStringValue(in.ActorUserID)
+	if actionType == "" || actorID == "" {
+		return false, nil
+	}
+	// Held until commit, so a concurrent prayer waits for this row to merge into.
+	if _, err := exec.Exec(ctx, `
+		SELECT pg_advisory_xact_lock(hashtext($1::text || ':' || $2::text))
+	`, in.UserID, in.SubjectID); err != nil {
+		log.Printf("notification merge failed: lock type=%s user=%s subject_id=%s err=%v", in.Type, in.UserID, in.SubjectID, err)
+		return false, err
+	}
+	ct, err := exec.Exec(ctx, `
+		WITH target AS (
+			SELECT id,
+			       COALESCE((payload->>'count')::int, 1) AS total,
+			       COALESCE(payload->'actionCounts', jsonb_build_object(COALESCE(payload->>'actionType', 'PRAYED'), 1)) AS action_counts,
+			       COALESCE(payload->'actorIds', CASE WHEN actor_user_id IS NULL THEN '[]'::jsonb ELSE jsonb_build_array(actor_user_id::text) END) AS actor_ids,
+			       COALESCE((payload->>'othersCount')::int, 0) AS others_count
+			FROM notifications
+			WHERE user_id = $1::uuid
+			  AND type = $2
+			  AND subject_type = $3
+			  AND subject_id = $4::uuid
+			  AND in_app
+			  AND read_at IS NULL
+			  AND created_at > NOW() - $7::bigint * INTERVAL '1 second'
+			ORDER BY created_at DESC
+			LIMIT 1
+			FOR UPDATE
+		),
+		updated AS (
+			UPDATE notifications n
+			SET actor_user_id = $5::uuid,
+			    payload = jsonb_build_object(
+			        'actionType', $6::text,
+			        'count', t.total + 1,
+			        'actionCounts', t.action_counts || jsonb_build_object($6::text, COALESCE((t.action_counts->>$6::text)::int, 0) + 1),
+			        'actorIds', to_jsonb((ARRAY[$5::text] || ARRAY(
+			            SELECT e.actor_id
+			            FROM jsonb_array_elements_text(t.actor_ids) WITH ORDINALITY e(actor_id, pos)
+			            WHERE e.actor_id <> $5::text
+			            ORDER BY e.pos
+			        ))[1:$8::int]),
+			        'othersCount', t.others_count + CASE WHEN t.actor_ids ? $5::text THEN 0 ELSE 1 END
+			    )
+			FROM target t
+			WHERE n.id = t.id
+			RETURNING n.id, n.user_id
+		)
+		SELECT pg_notify('`+notificationChannel+`', json_build_object('userId', user_id, 'notificationId', id)::text)
+		FROM updated
+	`, in.UserID, string(in.Type), string(in.SubjectType), in.SubjectID, actorID, actionType,
+		int64(prayedGroupWindow/time.Second), prayedActorSample)
+	if err != nil {
+		log.Printf("notification merge failed: type=%s user=%s subject_id=%s err=%v", in.Type, in.UserID, in.SubjectID, err)
+		return false, err
+	}
+	return ct.RowsAffected() > 0, nil
+}
+
+type deliveryPlan struct {
+	inApp           bool
+	emailSkipReason string
+	pushSkipReason  string
+	quietUntil      *time.Time
+}
+
+// insertNotificationOn wakes the in-app stream when the row is visible.
+func insertNotificationOn(ctx context.Context, exec notifyExec, in models.CreateNotificationInput, plan deliveryPlan) error {
+	payload := in.Payload
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("notification insert failed: payload marshal type=%s user=%s err=%v", in.Type, in.UserID, err)
+		return err
+	}
+	_, err = exec.Exec(ctx, `
+		WITH inserted AS (
+			INSERT INTO notifications (user_id, type, actor_user_id, subject_type, subject_id, payload, in_app,
+			                           email_skipped_at, email_last_error, email_next_attempt_at,
+			                           push_skipped_at, push_last_error, push_next_attempt_at)
+			VALUES ($1::uuid, $2, NULLIF($3, '')::uuid, $4, $5::uuid, $6::jsonb, $7,
+			        CASE WHEN $8::text <> '' THEN NOW() END, NULLIF($8::text, ''), COALESCE($9::timestamptz, NOW()),
+			        CASE WHEN $10::text <> '' THEN NOW() END, NULLIF($10::text, ''), COALESCE($9::timestamptz, NOW()))
+			RETURNING id, user_id, in_app
+		)
+		SELECT pg_notify('`+notificationChannel+`', json_build_object('userId', user_id, 'notificationId', id)::text)
+		FROM inserted
+		WHERE in_app
+	`, in.UserID, string(in.Type), nullableStringValue(in.ActorUserID), string(in.SubjectType), in.SubjectID, string(payloadBytes),
+		plan.inApp, plan.emailSkipReason, plan.quietUntil, plan.pushSkipReason)
+	if err != nil {
+		log.Printf("notification insert failed: type=%s user=%s subject_type=%s subject_id=%s err=%v", in.Type, in.UserID, in.SubjectType, in.SubjectID, err)
+	}
+	return err
+}
+
+// insertNotificationInTx inserts a notification inside a SAVEPOINT so a failure
+// (FK violation, type mismatch, etc.) doesn't poison the outer transaction.
+// Notifications are best-effort: callers ignore the error.
+func insertNotificationInTx(ctx context.Context, tx pgx.Tx, in models.CreateNotificationInput) error {
+	sub, err := tx.Begin(ctx)
+	if err != nil {
+		log.Printf("notification insert failed: in_tx savepoint begin type=%s user=%s err=%v", in.Type, in.UserID, err)
+		return err
+	}
+	if err := dispatchNotification(ctx, sub, in); err != nil {
+		_ = sub.Rollback(ctx)
+		return err
+	}
+	if err := sub.Commit(ctx); err != nil {
+		log.Printf("notification insert failed: in_tx savepoint commit type=%s user=%s err=%v", in.Type, in.UserID, err)
+		return err
+	}
+	return nil
+}
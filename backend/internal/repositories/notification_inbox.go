@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"parish-viva/backend/internal/models"
+)
+
+// notificationTypeArg passes no types as NULL so it does not filter.
+func notificationTypeArg(types []models.NotificationType) []string {
+	var out []string
+	for _, t := range types {
+		out = append(out, string(t))
+	}
+	return out
+}
+
+func (r *PostgresRepository) CountUnreadNotificationsByType(ctx context.Context, userID string) (map[models.NotificationType]int64, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT type, COUNT(*)::bigint
+		FROM notifications
+		WHERE user_id = $1 AND in_app AND read_at IS NULL
+		GROUP BY type
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[models.NotificationType]int64{}
+	for rows.Next() {
+		var (
+			t     models.NotificationType
+			count int64
+		)
+		if err = rows.Scan(&t, &count); err != nil {
+			return nil, err
+		}
+		counts[t] = count
+	}
+	return counts, rows.Err()
+}
+
+func (r *PostgresRepository) DeleteNotification(ctx context.Context, userID, id string) error {
+	var found, wasUnread bool
+	err := r.db.QueryRow(ctx, `
+		WITH deleted AS (
+			DELETE FROM notifications
+			WHERE id = $1 AND user_id = $2 AND in_app
+			RETURNING read_at IS NULL AS unread
+		)
+		SELECT COUNT(*) > 0, COALESCE(bool_or(unread), FALSE) FROM deleted
+	`, id, userID).Scan(&found, &wasUnread)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	if wasUnread {
+		signalNotificationsRead(ctx, r.db, userID)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) DeleteReadNotifications(ctx context.Context, userID string) (int64, error) {
+	ct, err := r.db.Exec(ctx, `
+		DELETE FROM notifications
+		WHERE user_id = $1 AND in_app AND read_at IS NOT NULL
+	`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// PurgeNotifications deletes up to limit notifications past retention.
+func (r *PostgresRepository) PurgeNotifications(ctx context.Context, olderThan time.Duration, limit int) (int64, error) {
+	ct, err := r.db.Exec(ctx, `
+		DELETE FROM notifications
+		WHERE id IN (
+			SELECT id
+			FROM notifications
+			WHERE (in_app AND read_at < NOW() - $1::bigint * INTERVAL '1 second')
+			   OR (NOT in_app
+			       AND created_at < NOW() - $1::bigint * INTERVAL '1 second'
+			       AND (sent_email_at IS NOT NULL OR email_skipped_at IS NOT NULL)
+			       AND (push_sent_at IS NOT NULL OR push_skipped_at IS NOT NULL))
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`, int64(olderThan/time.Second), limit)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+func deleteNotificationsWhere(ctx context.Context, tx pgx.Tx, cond string, args ...any) error {
+	rows, err := tx.Query(ctx, `
+		WITH deleted AS (
+			DELETE FROM notifications
+			WHERE `+cond+`
+			RETURNING user_id, in_app AND read_at IS NULL AS unread
+		)
+		SELECT DISTINCT user_id::text FROM deleted WHERE unread
+	`, args...)
+	if err != nil {
+		return err
+	}
+	recipients, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+	for _, recipient := range recipients {
+		signalNotificationsRead(ctx, tx, recipient)
+	}
+	return nil
+}
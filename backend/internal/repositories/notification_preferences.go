@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"parish-viva/backend/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrUnsubscribeTokenNotFound = errors.New("unsubscribe token not found")
+
+func (r *PostgresRepository) GetNotificationPreferences(ctx context.Context, userID string) (models.NotificationPreferences, error) {
+	var (
+		p             models.NotificationPreferences
+		channelsBytes []byte
+		quietStart    *string
+		quietEnd      *string
+	)
+	err := r.db.QueryRow(ctx, `
+		SELECT u.email_notifications_enabled, COALESCE(np.email_digest, 'OFF'), COALESCE(np.timezone, $2),
+		       COALESCE(np.type_channels, '{}'::jsonb),
+		       to_char(np.quiet_hours_start, 'HH24:MI'), to_char(np.quiet_hours_end, 'HH24:MI'),
+		       ARRAY(
+		           SELECT m.group_id::text
+		           FROM notification_group_mutes m
+		           INNER JOIN groups g ON g.id = m.group_id AND g.deleted_at IS NULL
+		           WHERE m.user_id = u.id
+		           ORDER BY m.created_at, m.group_id
+		       )
+		FROM users u
+		LEFT JOIN notification_preferences np ON np.user_id = u.id
+		WHERE u.id = $1 AND u.deleted_at IS NULL
+	`, userID, models.DefaultTimezone).Scan(&p.EmailEnabled, &p.EmailDigest, &p.Timezone,
+		&channelsBytes, &quietStart, &quietEnd, &p.MutedGroupIDs)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.NotificationPreferences{}, ErrUserNotFound
+	}
+	if err != nil {
+		return models.NotificationPreferences{}, err
+	}
+
+	stored := map[models.NotificationType]models.NotificationChannels{}
+	if err = json.Unmarshal(channelsBytes, &stored); err != nil {
+		return models.NotificationPreferences{}, err
+	}
+	p.Types = make(map[models.NotificationType]models.NotificationChannels, len(models.NotificationTypes))
+	for _, t := range models.NotificationTypes {
+		channels, ok := stored[t]
+		if !ok {
+			channels = models.DefaultNotificationChannels
+		}
+		p.Types[t] = channels
+	}
+	if quietStart != nil && quietEnd != nil {
+		p.QuietHours = &models.QuietHours{Start: *quietStart, End: *quietEnd}
+	}
+	if p.MutedGroupIDs == nil {
+		p.MutedGroupIDs = []string{}
+	}
+	return p, nil
+}
+
+// UpdateNotificationPreferences starts a new digest at its next slot, not now.
+func (r *PostgresRepository) UpdateNotificationPreferences(ctx context.Context, userID string, in models.UpdateNotificationPreferencesInput) (models.NotificationPreferences, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.NotificationPreferences{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	ct, err := tx.Exec(ctx, `
+		UPDATE users
+		SET email_notifications_enabled = COALESCE($2, email_notifications_enabled), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`, userID, in.EmailEnabled)
+	if err != nil {
+		return models.NotificationPreferences{}, err
+	}
+	if ct.RowsAffected() == 0 {
+		return models.NotificationPreferences{}, ErrUserNotFound
+	}
+
+	var typesJSON *string
+	if len(in.Types) > 0 {
+		b, err := json.Marshal(in.Types)
+		if err != nil {
+			return models.NotificationPreferences{}, err
+		}
+		encoded := string(b)
+		typesJSON = &encoded
+	}
+	var quietStart, quietEnd *string
+	if in.QuietHours != nil {
+		quietStart, quietEnd = &in.QuietHours.Start, &in.QuietHours.End
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO notification_preferences (user_id, email_digest, timezone, digest_last_sent_at,
+		                                      type_channels, quiet_hours_start, quiet_hours_end)
+		VALUES ($1, COALESCE($2, 'OFF'), COALESCE($3, $4), CASE WHEN COALESCE($2, 'OFF') <> 'OFF' THEN NOW() END,
+		        COALESCE($5::jsonb, '{}'::jsonb), $7::text::time, $8::text::time)
+		ON CONFLICT (user_id) DO UPDATE
+		SET email_digest = COALESCE($2, notification_preferences.email_digest),
+		    timezone = COALESCE($3, notification_preferences.timezone),
+		    digest_last_sent_at = CASE
+		        WHEN notification_preferences.email_digest = 'OFF' AND COALESCE($2, 'OFF') <> 'OFF' THEN NOW()
+		        ELSE notification_preferences.digest_last_sent_at
+		    END,
+		    type_channels = notification_preferences.type_channels || COALESCE($5::jsonb, '{}'::jsonb),
+		    quiet_hours_start = CASE WHEN $6 THEN $7::text::time ELSE notification_preferences.quiet_hours_start END,
+		    quiet_hours_end = CASE WHEN $6 THEN $8::text::time ELSE notification_preferences.quiet_hours_end END,
+		    updated_at = NOW()
+	`, userID, in.EmailDigest, in.Timezone, models.DefaultTimezone, typesJSON, in.SetQuietHours, quietStart, quietEnd)
+	if err != nil {
+		return models.NotificationPreferences{}, err
+	}
+
+	if in.MutedGroupIDs != nil {
+		groupIDs := *in.MutedGroupIDs
+		if _, err = tx.Exec(ctx, `
+			DELETE FROM notification_group_mutes
+			WHERE user_id = $1 AND NOT (group_id = ANY($2::uuid[]))
+		`, userID, groupIDs); err != nil {
+			return models.NotificationPreferences{}, err
+		}
+		var found int
+		err = tx.QueryRow(ctx, `
+			WITH wanted AS (
+				SELECT g.id
+				FROM groups g
+				WHERE g.id = ANY($2::uuid[]) AND g.deleted_at IS NULL
+			),
+			added AS (
+				INSERT INTO notification_group_mutes (user_id, group_id)
+				SELECT $1, id FROM wanted
+				ON CONFLICT (user_id, group_id) DO NOTHING
+			)
+			SELECT COUNT(*)::int FROM wanted
+		`, userID, groupIDs).Scan(&found)
+		if err != nil {
+			return models.NotificationPreferences{}, err
+		}
+		if found != len(groupIDs) {
+			return models.NotificationPreferences{}, ErrGroupNotFound
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.NotificationPreferences{}, err
+	}
+	return r.GetNotificationPreferences(ctx, userID)
+}
+
+func (r *PostgresRepository) UnsubscribeFromEmails(ctx context.Context, token string) error {
+	ct, err := r.db.Exec(ctx, `
+		UPDATE users u
+		SET email_notifications_enabled = FALSE, updated_at = NOW()
+		FROM notification_preferences np
+		WHERE np.unsubscribe_token = $1 AND u.id = np.user_id
+	`, token)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return ErrUnsubscribeTokenNotFound
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"parish-viva/backend/internal/models"
+)
+
+// notificationChannel announces notification changes to every replica.
+const notificationChannel = "notifications"
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// signalNotificationsRead is best-effort, like notifications themselves.
+func signalNotificationsRead(ctx context.Context, exec notifyExec, userID string) {
+	_, err := exec.Exec(ctx, `
+		SELECT pg_notify('`+notificationChannel+`', json_build_object('userId', $1::text)::text)
+	`, userID)
+	if err != nil {
+		log.Printf("notification signal failed: user=%s err=%v", userID, err)
+	}
+}
+
+// ListenNotifications closes its connection so LISTEN never returns to the pool.
+func (r *PostgresRepository) ListenNotifications(ctx context.Context, handle func(models.NotificationSignal)) error {
+	pooled, err := r.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, `LISTEN `+notificationChannel); err != nil {
+		return err
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var signal models.NotificationSignal
+		if err = json.Unmarshal([]byte(n.Payload), &signal); err != nil || signal.UserID == "" {
+			continue
+		}
+		handle(signal)
+	}
+}
+
+func (r *PostgresRepository) GetNotification(ctx context.Context, userID, id string) (models.NotificationView, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT n.id::text, n.type, n.subject_type, n.subject_id::text, n.payload, n.read_at, n.created_at,
+		       a.id::text, a.username, a.display_name, a.avatar_url, `+notificationActorsSQL+`
+		FROM notifications n
+		LEFT JOIN users a ON a.id = n.actor_user_id AND a.deleted_at IS NULL
+		WHERE n.id = $1 AND n.user_id = $2 AND n.in_app
+	`, id, userID)
+	if err != nil {
+		return models.NotificationView{}, err
+	}
+	defer rows.Close()
+	items, err := scanNotificationViews(rows)
+	if err != nil {
+		return models.NotificationView{}, err
+	}
+	if len(items) == 0 {
+		return models.NotificationView{}, ErrNotificationNotFound
+	}
+	return items[0], nil
+}
+
+// ListNotificationsAfter replays what a reconnecting stream missed.
+func (r *PostgresRepository) ListNotificationsAfter(ctx context.Context, userID, afterID string, limit int) ([]models.NotificationView, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH last_seen AS (
+			SELECT created_at, id FROM notifications WHERE id = $2 AND user_id = $1
+		)
+		SELECT n.id::text, n.type, n.subject_type, n.subject_id::text, n.payload, n.read_at, n.created_at,
+		       a.id::text, a.username, a.display_name, a.avatar_url, `+notificationActorsSQL+`
+		FROM notifications n
+		INNER JOIN last_seen ls ON (n.created_at, n.id) > (ls.created_at, ls.id)
+		LEFT JOIN users a ON a.id = n.actor_user_id AND a.deleted_at IS NULL
+		WHERE n.user_id = $1 AND n.in_app
+		ORDER BY n.created_at, n.id
+		LIMIT $3
+	`, userID, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanNotificationViews(rows)
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"parish-viva/backend/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ClosePrayerRequest notifies everyone who prayed once committed.
+func (r *PostgresRepository) ClosePrayerRequest(ctx context.Context, in models.ClosePrayerRequestInput) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		authorID  string
+		status    models.PrayerStatus
+		anonymous bool
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT author_id::text, status, allow_anonymous
+		FROM prayer_requests
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`, in.RequestID).Scan(&authorID, &status, &anonymous)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPrayerRequestNotFound
+		}
+		return err
+	}
+	if authorID != in.AuthorID {
+		return ErrPrayerRequestForbidden
+	}
+	if status != models.StatusActive {
+		return ErrPrayerRequestNotActive
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE prayer_requests
+		SET status = 'CLOSED',
+			resolution = $2,
+			testimony = NULLIF($3, ''),
+			closed_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1
+	`, in.RequestID, string(in.Resolution), in.Testimony)
+	if err != nil {
+		return err
+	}
+
+	notificationType := models.NotificationTypeRequestClosed
+	if in.Resolution == models.ResolutionAnswered {
+		notificationType = models.NotificationTypeRequestAnswered
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+	r.notifyPrayersOn(ctx, in.RequestID, authorID, anonymous, notificationType, map[string]any{"resolution": string(in.Resolution)})
+	return nil
+}
+
+// notifyPrayersOn leaves the author out as actor on anonymous requests.
+func (r *PostgresRepository) notifyPrayersOn(ctx context.Context, requestID, authorID string, anonymous bool, notificationType models.NotificationType, payload map[string]any) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT pa.user_id::text
+		FROM prayer_actions pa
+		INNER JOIN users u ON u.id = pa.user_id
+		WHERE pa.prayer_request_id = $1
+		  AND pa.user_id <> $2
+		  AND u.deleted_at IS NULL
+	`, requestID, authorID)
+	if err != nil {
+		log.Printf("notification dispatch failed: prayers type=%s request=%s err=%v", notificationType, requestID, err)
+		return
+	}
+	userIDs, err := scanIDs(rows)
+	if err != nil {
+		log.Printf("notification dispatch failed: prayers type=%s request=%s err=%v", notificationType, requestID, err)
+		return
+	}
+	var actor *string
+	if !anonymous {
+		actor = &authorID
+	}
+	for _, userID := range userIDs {
+		_ = dispatchNotification(ctx, r.db, models.CreateNotificationInput{
+			UserID:      userID,
+			Type:        notificationType,
+			ActorUserID: actor,
+			SubjectType: models.NotificationSubjectPrayerRequest,
+			SubjectID:   requestID,
+			Payload:     payload,
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"parish-viva/backend/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *PostgresRepository) CreatePrayerUpdate(ctx context.Context, authorID, requestID, body string) (models.PrayerUpdate, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.PrayerUpdate{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		ownerID   string
+		status    models.PrayerStatus
+		anonymous bool
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT author_id::text, status, allow_anonymous
+		FROM prayer_requests
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`, requestID).Scan(&ownerID, &status, &anonymous)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PrayerUpdate{}, ErrPrayerRequestNotFound
+		}
+		return models.PrayerUpdate{}, err
+	}
+	if ownerID != authorID {
+		return models.PrayerUpdate{}, ErrPrayerRequestForbidden
+	}
+	if status == models.StatusRemoved {
+		return models.PrayerUpdate{}, ErrPrayerRequestForbidden
+	}
+
+	var update models.PrayerUpdate
+	err = tx.QueryRow(ctx, `
+		INSERT INTO prayer_request_updates (prayer_request_id, author_id, body)
+		VALUES ($1, $2, $3)
+		RETURNING id::text, prayer_request_id::text, author_id::text, body, created_at
+	`, requestID, authorID, body).Scan(&update.ID, &update.PrayerRequestID, &update.AuthorID, &update.Body, &update.CreatedAt)
+	if err != nil {
+		return models.PrayerUpdate{}, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE prayer_requests
+		SET last_activity_at = NOW(), archive_warned_at = NULL
+		WHERE id = $1
+	`, requestID)
+	if err != nil {
+		return models.PrayerUpdate{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.PrayerUpdate{}, err
+	}
+	if status == models.StatusActive {
+		r.notifyPrayersOn(ctx, requestID, authorID, anonymous, models.NotificationTypeRequestUpdated, map[string]any{"updateId": update.ID})
+	}
+	return update, nil
+}
+
+func (r *PostgresRepository) ListPrayerUpdates(ctx context.Context, requestID string, limit, offset int) ([]models.PrayerUpdate, int64, error) {
+	var total int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)::bigint
+		FROM prayer_request_updates
+		WHERE prayer_request_id = $1
+	`, requestID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id::text, prayer_request_id::text, author_id::text, body, created_at
+		FROM prayer_request_updates
+		WHERE prayer_request_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, requestID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]models.PrayerUpdate, 0)
+	for rows.Next() {
+		var update models.PrayerUpdate
+		if err = rows.Scan(&update.ID, &update.PrayerRequestID, &update.AuthorID, &update.Body, &update.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, update)
+	}
+	return items, total, rows.Err()
+}
+
+func (r *PostgresRepository) getLatestPrayerUpdate(ctx context.Context, requestID string) (*models.PrayerUpdate, error) {
+	var update models.PrayerUpdate
+	err := r.db.QueryRow(ctx, `
+		SELECT id::text, prayer_request_id::text, author_id::text, body, created_at
+		FROM prayer_request_updates
+		WHERE prayer_request_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`, requestID).Scan(&update.ID, &update.PrayerRequestID, &update.AuthorID, &update.Body, &update.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &update, nil
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"parish-viva/backend/internal/models"
+)
+
+var ErrFriendRequestsNotAllowed = errors.New("friend requests not allowed")
+
+// privacyDefaultArgs stands in for users without a privacy_settings row.
+func privacyDefaultArgs() []any {
+	d := models.DefaultPrivacySettings
+	return []any{string(d.ProfileVisibility), string(d.StatsVisibility), string(d.FriendRequests), d.Searchable}
+}
+
+func (r *PostgresRepository) GetPrivacySettings(ctx context.Context, userID string) (models.PrivacySettings, error) {
+	var p models.PrivacySettings
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(ps.profile_visibility, $2::text), COALESCE(ps.stats_visibility, $3::text),
+		       COALESCE(ps.friend_requests, $4::text), COALESCE(ps.searchable, $5::boolean)
+		FROM users u
+		LEFT JOIN privacy_settings ps ON ps.user_id = u.id
+		WHERE u.id = $1 AND u.deleted_at IS NULL
+	`, append([]any{userID}, privacyDefaultArgs()...)...).Scan(&p.ProfileVisibility, &p.StatsVisibility, &p.FriendRequests, &p.Searchable)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.PrivacySettings{}, ErrUserNotFound
+	}
+	return p, err
+}
+
+// UpdatePrivacySettings saves the fields set in in and returns the result.
+func (r *PostgresRepository) UpdatePrivacySettings(ctx context.Context, userID string, in models.UpdatePrivacySettingsInput) (models.PrivacySettings, error) {
+	var p models.PrivacySettings
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO privacy_settings (user_id, profile_visibility, stats_visibility, friend_requests, searchable)
+		SELECT u.id, COALESCE($2, $6::text), COALESCE($3, $7::text), COALESCE($4, $8::text), COALESCE($5, $9::boolean)
+		FROM users u
+		WHERE u.id = $1 AND u.deleted_at IS NULL
+		ON CONFLICT (user_id) DO UPDATE
+		SET profile_visibility = COALESCE($2, privacy_settings.profile_visibility),
+		    stats_visibility = COALESCE($3, privacy_settings.stats_visibility),
+		    friend_requests = COALESCE($4, privacy_settings.friend_requests),
+		    searchable = COALESCE($5, privacy_settings.searchable),
+		    updated_at = NOW()
+		RETURNING profile_visibility, stats_visibility, friend_requests, searchable
+	`, append([]any{userID, in.ProfileVisibility, in.StatsVisibility, in.FriendRequests, in.Searchable}, privacyDefaultArgs()...)...).Scan(
+		&p.ProfileVisibility, &p.StatsVisibility, &p.FriendRequests, &p.Searchable)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.PrivacySettings{}, ErrUserNotFound
+	}
+	return p, err
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"parish-viva/backend/internal/models"
+)
+
+// GetProfileConnection leaves anonymous requests out of the last prayer.
+func (r *PostgresRepository) GetProfileConnection(ctx context.Context, viewerID, ownerID string, sampleSize int) (models.ProfileConnection, error) {
+	var (
+		c           models.ProfileConnection
+		mutualBytes []byte
+		groupsBytes []byte
+	)
+	err := r.db.QueryRow(ctx, `
+		WITH viewer_friends AS (
+			SELECT CASE WHEN user_id = $1 THEN friend_user_id ELSE user_id END AS id
+			FROM friendships
+			WHERE status = 'ACCEPTED' AND (user_id = $1 OR friend_user_id = $1)
+		),
+		owner_friends AS (
+			SELECT CASE WHEN user_id = $2 THEN friend_user_id ELSE user_id END AS id
+			FROM friendships
+			WHERE status = 'ACCEPTED' AND (user_id = $2 OR friend_user_id = $2)
+		),
+		mutual AS (
+			SELECT u.id, u.username, u.display_name, u.avatar_url
+			FROM viewer_friends vf
+			INNER JOIN owner_friends ofr ON ofr.id = vf.id
+			INNER JOIN users u ON u.id = vf.id AND u.deleted_at IS NULL
+		)
+		SELECT
+			(SELECT COUNT(*)::bigint FROM mutual),
+			(SELECT COALESCE(jsonb_agg(jsonb_build_object('userId', m.id::text, 'username', m.username,
+			                                              'displayName', m.display_name, 'avatarUrl', m.avatar_url)
+			                           ORDER BY m.display_name), '[]'::jsonb)
+			 FROM (SELECT * FROM mutual ORDER BY display_name LIMIT $3) m),
+			(SELECT COALESCE(jsonb_agg(jsonb_build_object('id', g.id::text, 'name', g.name, 'imageUrl', g.image_url)
+			                           ORDER BY g.name), '[]'::jsonb)
+			 FROM groups g
+			 INNER JOIN group_memberships vm ON vm.group_id = g.id AND vm.user_id = $1 AND vm.deleted_at IS NULL
+			 INNER JOIN group_memberships om ON om.group_id = g.id AND om.user_id = $2 AND om.deleted_at IS NULL
+			 WHERE g.deleted_at IS NULL),
+			(SELECT MAX(pa.created_at)
+			 FROM prayer_requests pr
+			 INNER JOIN prayer_actions pa ON pa.prayer_request_id = pr.id AND pa.user_id = $1
+			 WHERE pr.author_id = $2 AND NOT pr.allow_anonymous AND pr.deleted_at IS NULL)
+	`, viewerID, ownerID, sampleSize).Scan(&c.MutualFriendCount, &mutualBytes, &groupsBytes, &c.LastPrayedAt)
+	if err != nil {
+		return models.ProfileConnection{}, err
+	}
+	if err = json.Unmarshal(mutualBytes, &c.MutualFriends); err != nil {
+		return models.ProfileConnection{}, err
+	}
+	if err = json.Unmarshal(groupsBytes, &c.SharedGroups); err != nil {
+		return models.ProfileConnection{}, err
+	}
+	return c, nil
+}
+
+// mutualFriendSQL is true when the two users share an accepted friend.
+func mutualFriendSQL(a, b string) string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1
+		FROM friendships mfa
+		INNER JOIN friendships mfb
+		        ON mfb.status = 'ACCEPTED'
+		       AND (mfb.user_id = %[2]s OR mfb.friend_user_id = %[2]s)
+		       AND CASE WHEN mfb.user_id = %[2]s THEN mfb.friend_user_id ELSE mfb.user_id END
+		         = CASE WHEN mfa.user_id = %[1]s THEN mfa.friend_user_id ELSE mfa.user_id END
+		WHERE mfa.status = 'ACCEPTED' AND (mfa.user_id = %[1]s OR mfa.friend_user_id = %[1]s)
+	)`, a, b)
+}
+
+func (r *PostgresRepository) HasMutualFriend(ctx context.Context, viewerID, ownerID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT `+mutualFriendSQL("$1::uuid", "$2::uuid"), viewerID, ownerID).Scan(&exists)
+	return exists, err
+}
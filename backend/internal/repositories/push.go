@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"parish-viva/backend/internal/models"
+)
+
+var ErrPushSubscriptionNotFound = errors.New("push subscription not found")
+
+// SavePushSubscription moves a reused endpoint to the new user.
+func (r *PostgresRepository) SavePushSubscription(ctx context.Context, userID string, sub models.PushSubscription) (models.PushSubscription, error) {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO push_subscriptions (user_id, endpoint, p256dh, auth)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (endpoint) DO UPDATE
+		SET user_id = EXCLUDED.user_id, p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth, updated_at = NOW()
+		RETURNING id::text
+	`, userID, sub.Endpoint, sub.P256dh, sub.Auth).Scan(&sub.ID)
+	return sub, err
+}
+
+// DeletePushSubscription removes one of the user's subscriptions by endpoint.
+func (r *PostgresRepository) DeletePushSubscription(ctx context.Context, userID, endpoint string) error {
+	ct, err := r.db.Exec(ctx, `
+		DELETE FROM push_subscriptions WHERE user_id = $1 AND endpoint = $2
+	`, userID, endpoint)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return ErrPushSubscriptionNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) DeleteGonePushSubscription(ctx context.Context, subscriptionID string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM push_subscriptions WHERE id = $1`, subscriptionID)
+	return err
+}
+
+// ClaimNotificationPushes leases due pushes like ClaimNotificationEmails.
+func (r *PostgresRepository) ClaimNotificationPushes(ctx context.Context, limit int, lease time.Duration) ([]models.NotificationPush, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT id
+			FROM notifications
+			WHERE push_sent_at IS NULL
+			  AND push_skipped_at IS NULL
+			  AND push_next_attempt_at <= NOW()
+			ORDER BY push_next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		),
+		claimed AS (
+			UPDATE notifications n
+			SET push_attempts = n.push_attempts + 1,
+			    push_next_attempt_at = NOW() + $2::bigint * INTERVAL '1 second'
+			FROM due
+			WHERE n.id = due.id
+			RETURNING n.id, n.user_id, n.type, n.actor_user_id, n.subject_type, n.subject_id, n.payload, n.push_attempts, n.created_at
+		)
+		SELECT c.id::text, c.user_id::text, c.type, c.subject_type, c.subject_id::text,
+		       COALESCE(pr.title, g.name, ''), c.payload,
+		       CASE WHEN pr.allow_anonymous AND pr.author_id = a.id THEN '' ELSE COALESCE(a.display_name, '') END,
+		       c.push_attempts, u.display_name, u.locale, c.created_at
+		FROM claimed c
+		INNER JOIN users u ON u.id = c.user_id
+		LEFT JOIN users a ON a.id = c.actor_user_id AND a.deleted_at IS NULL
+		LEFT JOIN prayer_requests pr ON c.subject_type = 'PRAYER_REQUEST' AND pr.id = c.subject_id
+		LEFT JOIN groups g ON c.subject_type = 'GROUP' AND g.id = c.subject_id
+		ORDER BY c.created_at
+	`, limit, int64(lease/time.Second))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.NotificationPush, 0)
+	recipients := make([]string, 0)
+	for rows.Next() {
+		var (
+			p            models.NotificationPush
+			userID       string
+			payloadBytes []byte
+		)
+		err = rows.Scan(&p.NotificationID, &userID, &p.Type, &p.SubjectType, &p.SubjectID,
+			&p.SubjectTitle, &payloadBytes, &p.ActorName, &p.Attempts,
+			&p.RecipientName, &p.Locale, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		p.Payload = map[string]any{}
+		if len(payloadBytes) > 0 {
+			if err = json.Unmarshal(payloadBytes, &p.Payload); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, p)
+		recipients = append(recipients, userID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	subRows, err := r.db.Query(ctx, `
+		SELECT id::text, user_id::text, endpoint, p256dh, auth
+		FROM push_subscriptions
+		WHERE user_id = ANY($1::uuid[])
+		ORDER BY created_at
+	`, recipients)
+	if err != nil {
+		return nil, err
+	}
+	defer subRows.Close()
+	byUser := map[string][]models.PushSubscription{}
+	for subRows.Next() {
+		var (
+			s      models.PushSubscription
+			userID string
+		)
+		if err = subRows.Scan(&s.ID, &userID, &s.Endpoint, &s.P256dh, &s.Auth); err != nil {
+			return nil, err
+		}
+		byUser[userID] = append(byUser[userID], s)
+	}
+	if err = subRows.Err(); err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Subscriptions = byUser[recipients[i]]
+	}
+	return items, nil
+}
+
+func (r *PostgresRepository) MarkNotificationPushSent(ctx context.Context, notificationID string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notifications
+		SET push_sent_at = NOW(), push_last_error = NULL
+		WHERE id = $1
+	`, notificationID)
+	return err
+}
+
+func (r *PostgresRepository) MarkNotificationPushSkipped(ctx context.Context, notificationID, reason string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notifications
+		SET push_skipped_at = NOW(), push_last_error = $2
+		WHERE id = $1
+	`, notificationID, reason)
+	return err
+}
+
+func (r *PostgresRepository) RetryNotificationPush(ctx context.Context, notificationID, lastError string, delay time.Duration) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notifications
+		SET push_last_error = $2,
+		    push_next_attempt_at = NOW() + $3::bigint * INTERVAL '1 second'
+		WHERE id = $1
+	`, notificationID, lastError, int64(delay/time.Second))
+	return err
+}
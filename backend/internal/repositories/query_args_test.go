@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var placeholderPattern = regexp.MustCompile(`\$(\d+)`)
+
+// TestQueryArgumentCounts checks each literal query passes as many args as its highest placeholder.
+func TestQueryArgumentCounts(t *testing.T) {
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := parser.ParseFile(fset, path, src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f)
+	}
+
+	consts := packageStrings(files)
+	checked := 0
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || call.Ellipsis.IsValid() || len(call.Args) < 2 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			switch sel.Sel.Name {
+			case "Query", "QueryRow", "Exec":
+			default:
+				return true
+			}
+			sql, ok := stringValue(call.Args[1], consts)
+			if !ok {
+				return true
+			}
+			highest := 0
+			for _, m := range placeholderPattern.FindAllStringSubmatch(sql, -1) {
+				if n, _ := strconv.Atoi(m[1]); n > highest {
+					highest = n
+				}
+			}
+			if got := len(call.Args) - 2; got != highest {
+				t.Errorf("%s: query uses $1-$%d but passes %d arguments", fset.Position(call.Pos()), highest, got)
+			}
+			checked++
+			return true
+		})
+	}
+	if checked == 0 {
+		t.Fatal("no queries checked")
+	}
+}
+
+// packageStrings collects package-level string constants and variables.
+func packageStrings(files []*ast.File) map[string]string {
+	values := map[string]string{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if i >= len(vs.Values) {
+						continue
+					}
+					if v, ok := stringValue(vs.Values[i], values); ok {
+						values[name.Name] = v
+					}
+				}
+			}
+		}
+	}
+	return values
+}
+
+// stringValue evaluates literals, known identifiers and their concatenation.
+func stringValue(expr ast.Expr, known map[string]string) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		v, err := strconv.Unquote(e.Value)
+		return v, err == nil
+	case *ast.Ident:
+		v, ok := known[e.Name]
+		return v, ok
+	case *ast.ParenExpr:
+		return stringValue(e.X, known)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, ok := stringValue(e.X, known)
+		if !ok {
+			return "", false
+		}
+		right, ok := stringValue(e.Y, known)
+		return left + right, ok
+	}
+	return "", false
+}
@@ -0,0 +1,256 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"parish-viva/backend/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateReport returns false with the open report when the reporter already filed one.
+func (r *PostgresRepository) CreateReport(ctx context.Context, in models.CreateReportInput) (models.Report, bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.Report{}, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var requestStatus models.PrayerStatus
+	if in.TargetRequestID != "" {
+		err = tx.QueryRow(ctx, `
+			SELECT status
+			FROM prayer_requests
+			WHERE id = $1 AND deleted_at IS NULL
+			FOR UPDATE
+		`, in.TargetRequestID).Scan(&requestStatus)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.Report{}, false, ErrPrayerRequestNotFound
+			}
+			return models.Report{}, false, err
+		}
+	}
+
+	var conflictTarget string
+	if in.TargetRequestID != "" {
+		conflictTarget = `(reporter_id, target_request_id) WHERE target_request_id IS NOT NULL AND status = 'OPEN'`
+	} else {
+		conflictTarget = `(reporter_id, target_user_id) WHERE target_user_id IS NOT NULL AND status = 'OPEN'`
+	}
+	report, err := scanReport(tx.QueryRow(ctx, `
+		INSERT INTO reports (reporter_id, target_request_id, target_user_id, reason, details)
+		VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, '')::uuid, $4, NULLIF($5, ''))
+		ON CONFLICT `+conflictTarget+` DO NOTHING
+		RETURNING id::text, reporter_id::text, target_request_id::text, target_user_id::text, reason, details, status, created_at, resolved_at
+	`, in.ReporterID, in.TargetRequestID, in.TargetUserID, string(in.Reason), in.Details))
+	if errors.Is(err, pgx.ErrNoRows) {
+		existing, err := scanReport(tx.QueryRow(ctx, `
+			SELECT id::text, reporter_id::text, target_request_id::text, target_user_id::text, reason, details, status, created_at, resolved_at
+			FROM reports
+			WHERE reporter_id = $1
+			  AND status = 'OPEN'
+			  AND (target_request_id = NULLIF($2, '')::uuid OR target_user_id = NULLIF($3, '')::uuid)
+		`, in.ReporterID, in.TargetRequestID, in.TargetUserID))
+		if err != nil {
+			return models.Report{}, false, err
+		}
+		return existing, false, nil
+	}
+	if err != nil {
+		return models.Report{}, false, err
+	}
+
+	var queueItemID string
+	if in.TargetRequestID != "" {
+		err = tx.QueryRow(ctx, `
+			INSERT INTO moderation_queue (prayer_request_id, reason, status, report_count)
+			VALUES ($1, 'REPORTED', 'PENDING', 1)
+			ON CONFLICT (prayer_request_id, COALESCE(group_id, '00000000-0000-0000-0000-000000000000'::uuid))
+				WHERE status <> 'RESOLVED'
+			DO UPDATE SET reason = 'REPORTED', report_count = moderation_queue.report_count + 1, updated_at = NOW()
+			RETURNING id::text
+		`, in.TargetRequestID).Scan(&queueItemID)
+	} else {
+		err = tx.QueryRow(ctx, `
+			INSERT INTO moderation_queue (target_user_id, reason, status, report_count)
+			VALUES ($1, 'REPORTED', 'PENDING', 1)
+			ON CONFLICT (target_user_id) WHERE prayer_request_id IS NULL AND status <> 'RESOLVED'
+			DO UPDATE SET report_count = moderation_queue.report_count + 1, updated_at = NOW()
+			RETURNING id::text
+		`, in.TargetUserID).Scan(&queueItemID)
+	}
+	if err != nil {
+		return models.Report{}, false, err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE reports SET queue_item_id = $2 WHERE id = $1
+	`, report.ID, queueItemID)
+	if err != nil {
+		return models.Report{}, false, err
+	}
+
+	// Enough distinct reporters hide a live request until a moderator decides.
+	if in.TargetRequestID != "" && in.AutoHideThreshold > 0 && requestStatus == models.StatusActive {
+		var openReports int
+		err = tx.QueryRow(ctx, `
+			SELECT COUNT(*)
+			FROM reports
+			WHERE target_request_id = $1 AND status = 'OPEN'
+		`, in.TargetRequestID).Scan(&openReports)
+		if err != nil {
+			return models.Report{}, false, err
+		}
+		if openReports >= in.AutoHideThreshold {
+			_, err = tx.Exec(ctx, `
+				UPDATE prayer_requests
+				SET status = 'PENDING_REVIEW', updated_at = NOW()
+				WHERE id = $1
+			`, in.TargetRequestID)
+			if err != nil {
+				return models.Report{}, false, err
+			}
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Report{}, false, err
+	}
+	return report, true, nil
+}
+
+func (r *PostgresRepository) DismissModerationItem(ctx context.Context, actorUserID, itemID, note string) (models.ModerationAction, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		status       models.ModerationQueueStatus
+		claimedBy    *string
+		claimStale   bool
+		targetUserID *string
+		groupID      *string
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT status, claimed_by::text, COALESCE(claimed_at < NOW() - INTERVAL '30 minutes', TRUE),
+		       target_user_id::text, group_id::text
+		FROM moderation_queue
+		WHERE id = $1
+		FOR UPDATE
+	`, itemID).Scan(&status, &claimedBy, &claimStale, &targetUserID, &groupID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ModerationAction{}, ErrModerationItemNotFound
+		}
+		return models.ModerationAction{}, err
+	}
+	if status == models.QueueStatusResolved {
+		return models.ModerationAction{}, ErrModerationItemResolved
+	}
+	if claimedBy != nil && *claimedBy != actorUserID && !claimStale {
+		return models.ModerationAction{}, ErrModerationItemClaimed
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE moderation_queue
+		SET status = 'RESOLVED', resolved_at = NOW(), resolved_by = $2, updated_at = NOW()
+		WHERE id = $1
+	`, itemID, actorUserID)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+
+	payload := map[string]any{"queueItemId": itemID}
+	if note != "" {
+		payload["note"] = note
+	}
+	action, err := insertModerationActionOn(ctx, tx, models.ModerationAction{
+		ActorUserID:   actorUserID,
+		TargetUserID:  targetUserID,
+		TargetGroupID: groupID,
+		Action:        models.ActionDismiss,
+		Payload:       payload,
+	})
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+
+	if err = resolveReportsOn(ctx, tx, []string{itemID}, actorUserID, models.ActionDismiss); err != nil {
+		return models.ModerationAction{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.ModerationAction{}, err
+	}
+	return action, nil
+}
+
+// resolveReportsOn tells reporters the outcome without naming the moderator.
+func resolveReportsOn(ctx context.Context, tx pgx.Tx, queueItemIDs []string, actorUserID string, outcome models.ModerationActionType) error {
+	if len(queueItemIDs) == 0 {
+		return nil
+	}
+	rows, err := tx.Query(ctx, `
+		UPDATE reports
+		SET status = 'RESOLVED', resolved_at = NOW()
+		WHERE queue_item_id::text = ANY($1::text[]) AND status = 'OPEN'
+		RETURNING id::text, reporter_id::text, target_request_id::text, target_user_id::text, reason, details, status, created_at, resolved_at
+	`, queueItemIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	resolved := make([]models.Report, 0)
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return err
+		}
+		resolved = append(resolved, report)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, report := range resolved {
+		if report.ReporterID == actorUserID {
+			continue
+		}
+		in := models.CreateNotificationInput{
+			UserID:  report.ReporterID,
+			Type:    models.NotificationTypeReportResolved,
+			Payload: map[string]any{"reportId": report.ID, "reason": string(report.Reason), "outcome": string(outcome)},
+		}
+		if report.TargetRequestID != nil {
+			in.SubjectType = models.NotificationSubjectPrayerRequest
+			in.SubjectID = *report.TargetRequestID
+		} else {
+			in.SubjectType = models.NotificationSubjectUser
+			in.SubjectID = derefStr(report.TargetUserID)
+		}
+		_ = insertNotificationInTx(ctx, tx, in)
+	}
+	return nil
+}
+
+func scanReport(row pgx.Row) (models.Report, error) {
+	var report models.Report
+	err := row.Scan(&report.ID, &report.ReporterID, &report.TargetRequestID, &report.TargetUserID, &report.Reason, &report.Details, &report.Status, &report.CreatedAt, &report.ResolvedAt)
+	return report, err
+}
+
+func scanIDs(rows pgx.Rows) ([]string, error) {
+	defer rows.Close()
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
 	"strings"
+	"time"
 
 	"parish-viva/backend/internal/models"
 
@@ -29,6 +29,19 @@ var ErrPrayerRequestForbidden = errors.New("prayer request forbidden")
 var ErrGroupNotFound = errors.New("group not found")
 var ErrGroupMembershipNotFound = errors.New("group membership not found")
 var ErrUserNotFound = errors.New("user not found")
+var ErrModerationItemNotFound = errors.New("moderation item not found")
+var ErrModerationItemClaimed = errors.New("moderation item claimed by another moderator")
+var ErrModerationItemResolved = errors.New("moderation item already resolved")
+var ErrModerationActionNotFound = errors.New("moderation action not found")
+var ErrModerationActionNotRevertible = errors.New("moderation action cannot be reverted")
+var ErrModerationActionReverted = errors.New("moderation action already reverted")
+var ErrModerationStateChanged = errors.New("prayer request status changed since the action")
+var ErrGroupBanned = errors.New("user is banned from group")
+var ErrGroupBanNotFound = errors.New("group ban not found")
+var ErrPrayerRequestNotActive = errors.New("prayer request is not active")
+var ErrCommentNotFound = errors.New("comment not found")
+var ErrCommentForbidden = errors.New("comment forbidden")
+var ErrInvalidCommentParent = errors.New("comment can only reply to a visible top-level comment on the same request")
 
 type Repository interface {
 	GetUserByID(ctx context.Context, userID string) (models.User, error)
@@ -36,21 +49,29 @@ type Repository interface {
 	UpdateUserProfile(ctx context.Context, userID, displayName, username string, avatarURL *string, setAvatar bool, bio *string, setBio bool) (models.User, error)
 	GetFriendshipState(ctx context.Context, viewerID, ownerID string) (models.PublicFriendshipState, *string, error)
 	GetUserStats(ctx context.Context, userID string) (models.ProfileStats, error)
+	GetProfileConnection(ctx context.Context, viewerID, ownerID string, sampleSize int) (models.ProfileConnection, error)
+	HasMutualFriend(ctx context.Context, viewerID, ownerID string) (bool, error)
+	GetPrivacySettings(ctx context.Context, userID string) (models.PrivacySettings, error)
+	UpdatePrivacySettings(ctx context.Context, userID string, in models.UpdatePrivacySettingsInput) (models.PrivacySettings, error)
 	UpsertAuthUser(ctx context.Context, userID, email, preferredUsername, preferredDisplayName, preferredTradition string) error
 	SetUserTradition(ctx context.Context, userID string, tradition models.Tradition) (models.User, error)
+	SetUserLocale(ctx context.Context, userID string, locale models.Locale) (models.User, error)
 	UsernameExists(ctx context.Context, username string) (bool, error)
 	CreatePrayerRequest(ctx context.Context, in models.CreatePrayerRequestInput) (models.PrayerRequest, error)
 	UpdatePrayerRequest(ctx context.Context, in models.UpdatePrayerRequestInput) (models.PrayerRequest, error)
 	DeletePrayerRequest(ctx context.Context, userID, requestID string) error
 	GetPrayerRequestByID(ctx context.Context, userID, requestID string) (models.PrayerRequest, error)
-	ListPublicPrayerRequests(ctx context.Context, viewerUserID string, limit, offset int) ([]models.PrayerRequest, error)
-	CountPublicPrayerRequests(ctx context.Context, viewerUserID string) (int64, error)
-	ListGroupsPrayerRequests(ctx context.Context, userID string, limit, offset int) ([]models.PrayerRequest, error)
-	CountGroupsPrayerRequests(ctx context.Context, userID string) (int64, error)
-	ListFriendsPrayerRequests(ctx context.Context, userID string, limit, offset int) ([]models.PrayerRequest, error)
-	CountFriendsPrayerRequests(ctx context.Context, userID string) (int64, error)
-	ListHomePrayerRequests(ctx context.Context, userID string, limit, offset int) ([]models.PrayerRequest, error)
-	CountHomePrayerRequests(ctx context.Context, userID string) (int64, error)
+	ListPublicPrayerRequests(ctx context.Context, viewerUserID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error)
+	CountPublicPrayerRequests(ctx context.Context, viewerUserID string, filter models.FeedFilter) (int64, error)
+	ListGroupsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error)
+	CountGroupsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter) (int64, error)
+	ListFriendsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error)
+	CountFriendsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter) (int64, error)
+	ListHomePrayerRequests(ctx context.Context, userID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error)
+	CountHomePrayerRequests(ctx context.Context, userID string, filter models.FeedFilter) (int64, error)
+	ListHomeFeedCandidates(ctx context.Context, userID string, filter models.FeedFilter, limit int) ([]models.FeedCandidate, error)
+	ListPrayerRequestsByIDs(ctx context.Context, userID string, ids []string) ([]models.PrayerRequest, error)
+	SearchPrayerRequests(ctx context.Context, in models.SearchPrayerRequestsInput) ([]models.PrayerRequestSearchHit, int64, error)
 	RecordPrayerAction(ctx context.Context, userID, requestID string, actionType models.PrayerActionType, windowHours int) error
 	ListUserGroups(ctx context.Context, userID string) ([]models.Group, error)
 	SearchGroupsByName(ctx context.Context, userID, query string, limit int) ([]models.GroupSummary, error)
@@ -60,24 +81,86 @@ type Repository interface {
 	ApproveGroupJoinRequest(ctx context.Context, actorUserID, groupID, requestID string) error
 	RejectGroupJoinRequest(ctx context.Context, actorUserID, groupID, requestID string) error
 	GetGroupDetails(ctx context.Context, viewerUserID, groupID string) (models.GroupDetails, error)
-	ListGroupMembers(ctx context.Context, groupID string, limit, offset int) ([]models.GroupMember, int64, error)
+	ListGroupMembers(ctx context.Context, viewerUserID, groupID string, limit, offset int) ([]models.GroupMember, int64, error)
 	GetGroupRoleOf(ctx context.Context, userID, groupID string) (models.GroupRole, bool, error)
 	CountGroupAdmins(ctx context.Context, groupID string) (int64, error)
 	ChangeMemberRole(ctx context.Context, groupID, targetUserID string, newRole models.GroupRole) error
 	RemoveMember(ctx context.Context, groupID, targetUserID string) error
 	UpdateGroup(ctx context.Context, groupID string, in models.UpdateGroupInput) (models.Group, error)
-	ListPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string, limit, offset int) ([]models.PrayerRequest, error)
-	CountPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string) (int64, error)
+	ListPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error)
+	CountPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string, filter models.FeedFilter) (int64, error)
 	SendFriendRequest(ctx context.Context, fromUserID, targetUsername string) error
 	ListFriends(ctx context.Context, userID string) ([]models.Friend, error)
 	ListPendingFriendRequests(ctx context.Context, userID string) ([]models.FriendRequest, error)
 	AcceptFriendRequest(ctx context.Context, userID, requestID string) error
+	ListOutgoingFriendRequests(ctx context.Context, userID string) ([]models.OutgoingFriendRequest, error)
+	RejectFriendRequest(ctx context.Context, userID, requestID string) error
+	CancelFriendRequest(ctx context.Context, userID, requestID string) error
+	RemoveFriend(ctx context.Context, userID, friendUserID string) error
+	ListFriendSuggestions(ctx context.Context, userID string, prayerWindow time.Duration, limit int) ([]models.FriendSuggestion, error)
+	DismissFriendSuggestion(ctx context.Context, userID, dismissedUserID string) error
+	IsBlocked(ctx context.Context, userID, otherUserID string) (bool, error)
+	BlockUser(ctx context.Context, userID, username string) (models.BlockedUser, error)
+	UnblockUser(ctx context.Context, userID, username string) error
+	ListBlockedUsers(ctx context.Context, userID string) ([]models.BlockedUser, error)
 	SearchUsersForFriendship(ctx context.Context, userID, query string, limit int) ([]models.UserSummary, error)
 	CreateNotification(ctx context.Context, in models.CreateNotificationInput) error
-	ListNotifications(ctx context.Context, userID string, limit, offset int) ([]models.NotificationView, error)
+	ListNotifications(ctx context.Context, userID string, filter models.NotificationFilter, limit, offset int) ([]models.NotificationView, error)
 	CountUnreadNotifications(ctx context.Context, userID string) (int64, error)
+	CountUnreadNotificationsByType(ctx context.Context, userID string) (map[models.NotificationType]int64, error)
+	DeleteNotification(ctx context.Context, userID, id string) error
+	DeleteReadNotifications(ctx context.Context, userID string) (int64, error)
+	PurgeNotifications(ctx context.Context, olderThan time.Duration, limit int) (int64, error)
 	MarkNotificationRead(ctx context.Context, userID, id string) error
 	MarkAllNotificationsRead(ctx context.Context, userID string) error
+	GetNotification(ctx context.Context, userID, id string) (models.NotificationView, error)
+	ListNotificationsAfter(ctx context.Context, userID, afterID string, limit int) ([]models.NotificationView, error)
+	ClaimNotificationEmails(ctx context.Context, limit int, lease time.Duration) ([]models.NotificationEmail, error)
+	MarkNotificationEmailSent(ctx context.Context, notificationID string) error
+	MarkNotificationEmailSkipped(ctx context.Context, notificationID, reason string) error
+	RetryNotificationEmail(ctx context.Context, notificationID, lastError string, delay time.Duration) error
+	GetNotificationPreferences(ctx context.Context, userID string) (models.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, userID string, in models.UpdateNotificationPreferencesInput) (models.NotificationPreferences, error)
+	UnsubscribeFromEmails(ctx context.Context, token string) error
+	ClaimDueDigests(ctx context.Context, sendHour, limit int) ([]models.DigestRecipient, error)
+	RestoreDigestSchedule(ctx context.Context, userID string, previousSentAt *time.Time) error
+	GetEmailDigest(ctx context.Context, userID string, since time.Time, limit int) (models.EmailDigest, error)
+	SavePushSubscription(ctx context.Context, userID string, sub models.PushSubscription) (models.PushSubscription, error)
+	DeletePushSubscription(ctx context.Context, userID, endpoint string) error
+	DeleteGonePushSubscription(ctx context.Context, subscriptionID string) error
+	ClaimNotificationPushes(ctx context.Context, limit int, lease time.Duration) ([]models.NotificationPush, error)
+	MarkNotificationPushSent(ctx context.Context, notificationID string) error
+	MarkNotificationPushSkipped(ctx context.Context, notificationID, reason string) error
+	RetryNotificationPush(ctx context.Context, notificationID, lastError string, delay time.Duration) error
+	ListenNotifications(ctx context.Context, handle func(models.NotificationSignal)) error
+	GetPlatformRole(ctx context.Context, userID string) (models.PlatformRole, error)
+	ListModeratedGroupIDs(ctx context.Context, userID string) ([]string, error)
+	FilterGroupsRequiringModeration(ctx context.Context, groupIDs []string) ([]string, error)
+	IsGroupBanned(ctx context.Context, groupID, userID string) (bool, error)
+	BanGroupMember(ctx context.Context, in models.CreateGroupBanInput) (models.GroupBan, error)
+	UnbanGroupMember(ctx context.Context, actorUserID, groupID, userID string) error
+	ListGroupBans(ctx context.Context, groupID string, limit, offset int) ([]models.GroupBan, int64, error)
+	CreateReport(ctx context.Context, in models.CreateReportInput) (models.Report, bool, error)
+	DismissModerationItem(ctx context.Context, actorUserID, itemID, note string) (models.ModerationAction, error)
+	CreatePrayerUpdate(ctx context.Context, authorID, requestID, body string) (models.PrayerUpdate, error)
+	ListPrayerUpdates(ctx context.Context, requestID string, limit, offset int) ([]models.PrayerUpdate, int64, error)
+	ClosePrayerRequest(ctx context.Context, in models.ClosePrayerRequestInput) error
+	KeepPrayerRequestActive(ctx context.Context, authorID, requestID string) error
+	ArchiveStalePrayerRequests(ctx context.Context, policy models.ArchivalPolicy) (models.ArchivalResult, error)
+	CreateComment(ctx context.Context, in models.CreateCommentInput) (models.Comment, error)
+	ListComments(ctx context.Context, viewerID, requestID string, limit, offset int) ([]models.Comment, int64, error)
+	ListCommentReplies(ctx context.Context, viewerID, requestID, commentID string, limit, offset int) ([]models.Comment, int64, error)
+	DeleteComment(ctx context.Context, userID, requestID, commentID string) error
+	ApplyCommentDecision(ctx context.Context, d models.CommentDecision) (models.ModerationAction, error)
+	EnqueueModerationItem(ctx context.Context, in models.EnqueueModerationInput) error
+	ListModerationQueue(ctx context.Context, filter models.ModerationQueueFilter) ([]models.ModerationQueueItem, int64, error)
+	GetModerationQueueItem(ctx context.Context, itemID string) (models.ModerationQueueItem, error)
+	ClaimModerationQueueItem(ctx context.Context, itemID, moderatorID string) (models.ModerationQueueItem, error)
+	GetPrayerRequestForModeration(ctx context.Context, requestID string) (models.PrayerRequest, error)
+	ApplyModerationDecision(ctx context.Context, d models.ModerationDecision) (models.ModerationAction, error)
+	GetModerationAction(ctx context.Context, actionID string) (models.ModerationAction, error)
+	RevertModerationAction(ctx context.Context, actorUserID, actionID string) (models.ModerationAction, error)
+	ListModerationActions(ctx context.Context, requestID string) ([]models.ModerationAction, error)
 }
 
 type PostgresRepository struct {
@@ -91,20 +174,20 @@ func NewPostgresRepository(db *pgxpool.Pool) *PostgresRepository {
 func (r *PostgresRepository) GetUserByID(ctx context.Context, userID string) (models.User, error) {
 	var u models.User
 	err := r.db.QueryRow(ctx, `
-		SELECT id::text, email, username, display_name, avatar_url, bio, tradition, created_at, updated_at
+		SELECT id::text, email, username, display_name, avatar_url, bio, tradition, locale, created_at, updated_at
 		FROM users
 		WHERE id = $1 AND deleted_at IS NULL
-	`, userID).Scan(&u.ID, &u.Email, &u.Username, &u.DisplayName, &u.AvatarURL, &u.Bio, &u.Tradition, &u.CreatedAt, &u.UpdatedAt)
+	`, userID).Scan(&u.ID, &u.Email, &u.Username, &u.DisplayName, &u.AvatarURL, &u.Bio, &u.Tradition, &u.Locale, &u.CreatedAt, &u.UpdatedAt)
 	return u, err
 }
 
 func (r *PostgresRepository) GetUserByUsername(ctx context.Context, username string) (models.User, error) {
 	var u models.User
 	err := r.db.QueryRow(ctx, `
-		SELECT id::text, email, username, display_name, avatar_url, bio, tradition, created_at, updated_at
+		SELECT id::text, email, username, display_name, avatar_url, bio, tradition, locale, created_at, updated_at
 		FROM users
 		WHERE LOWER(username) = LOWER($1) AND deleted_at IS NULL
-	`, username).Scan(&u.ID, &u.Email, &u.Username, &u.DisplayName, &u.AvatarURL, &u.Bio, &u.Tradition, &u.CreatedAt, &u.UpdatedAt)
+	`, username).Scan(&u.ID, &u.Email, &u.Username, &u.DisplayName, &u.AvatarURL, &u.Bio, &u.Tradition, &u.Locale, &u.CreatedAt, &u.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.User{}, ErrUserNotFound
@@ -124,8 +207,8 @@ func (r *PostgresRepository) UpdateUserProfile(ctx context.Context, userID, disp
 		    bio = CASE WHEN $7::bool THEN $6 ELSE bio END,
 		    updated_at = NOW()
 		WHERE id = $1 AND deleted_at IS NULL
-		RETURNING id::text, email, username, display_name, avatar_url, bio, tradition, created_at, updated_at
-	`, userID, displayName, username, avatarURL, setAvatar, bio, setBio).Scan(&u.ID, &u.Email, &u.Username, &u.DisplayName, &u.AvatarURL, &u.Bio, &u.Tradition, &u.CreatedAt, &u.UpdatedAt)
+		RETURNING id::text, email, username, display_name, avatar_url, bio, tradition, locale, created_at, updated_at
+	`, userID, displayName, username, avatarURL, setAvatar, bio, setBio).Scan(&u.ID, &u.Email, &u.Username, &u.DisplayName, &u.AvatarURL, &u.Bio, &u.Tradition, &u.Locale, &u.CreatedAt, &u.UpdatedAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" && strings.Contains(pgErr.ConstraintName, "username") {
@@ -141,8 +224,19 @@ func (r *PostgresRepository) SetUserTradition(ctx context.Context, userID string
 		UPDATE users
 		SET tradition = $2, updated_at = NOW()
 		WHERE id = $1 AND deleted_at IS NULL
-		RETURNING id::text, email, username, display_name, avatar_url, bio, tradition, created_at, updated_at
-	`, userID, tradition).Scan(&u.ID, &u.Email, &u.Username, &u.DisplayName, &u.AvatarURL, &u.Bio, &u.Tradition, &u.CreatedAt, &u.UpdatedAt)
+		RETURNING id::text, email, username, display_name, avatar_url, bio, tradition, locale, created_at, updated_at
+	`, userID, tradition).Scan(&u.ID, &u.Email, &u.Username, &u.DisplayName, &u.AvatarURL, &u.Bio, &u.Tradition, &u.Locale, &u.CreatedAt, &u.UpdatedAt)
+	return u, err
+}
+
+func (r *PostgresRepository) SetUserLocale(ctx context.Context, userID string, locale models.Locale) (models.User, error) {
+	var u models.User
+	err := r.db.QueryRow(ctx, `
+		UPDATE users
+		SET locale = $2, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id::text, email, username, display_name, avatar_url, bio, tradition, locale, created_at, updated_at
+	`, userID, locale).Scan(&u.ID, &u.Email, &u.Username, &u.DisplayName, &u.AvatarURL, &u.Bio, &u.Tradition, &u.Locale, &u.CreatedAt, &u.UpdatedAt)
 	return u, err
 }
 
@@ -159,8 +253,9 @@ func (r *PostgresRepository) GetFriendshipState(ctx context.Context, viewerID, o
 	err := r.db.QueryRow(ctx, `
 		SELECT id::text, user_id::text, friend_user_id::text, status
 		FROM friendships
-		WHERE (user_id = $1 AND friend_user_id = $2)
-		   OR (user_id = $2 AND friend_user_id = $1)
+		WHERE status <> 'BLOCKED'
+		  AND ((user_id = $1 AND friend_user_id = $2)
+		    OR (user_id = $2 AND friend_user_id = $1))
 		LIMIT 1
 	`, viewerID, ownerID).Scan(&id, &userID, &friendID, &status)
 	if err != nil {
@@ -297,18 +392,30 @@ func (r *PostgresRepository) CreatePrayerRequest(ctx context.Context, in models.
 	}
 	defer tx.Rollback(ctx)
 
+	status := models.StatusActive
+	if len(in.ReviewGroupIDs) > 0 || in.ReviewPublic {
+		status = models.StatusPendingReview
+	}
+
 	var pr models.PrayerRequest
 	err = tx.QueryRow(ctx, `
-		INSERT INTO prayer_requests (author_id, title, body, category, visibility, allow_anonymous, status, tradition)
-		VALUES ($1, $2, $3, $4, $5, $6, 'ACTIVE', COALESCE((SELECT tradition FROM users WHERE id = $1), 'CATHOLIC'))
-		RETURNING id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, created_at, updated_at
-	`, in.AuthorID, in.Title, in.Body, in.Category, in.Visibility, in.AllowAnonymous).
-		Scan(&pr.ID, &pr.AuthorID, &pr.Title, &pr.Body, &pr.Category, &pr.Visibility, &pr.Tradition, &pr.AllowAnonymous, &pr.Status, &pr.PrayedCount, &pr.CreatedAt, &pr.UpdatedAt)
+		INSERT INTO prayer_requests (author_id, title, body, category, visibility, allow_anonymous, status, tradition, comments_enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE((SELECT tradition FROM users WHERE id = $1), 'CATHOLIC'), COALESCE($8::bool, TRUE))
+		RETURNING id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, comments_enabled, created_at, updated_at
+	`, in.AuthorID, in.Title, in.Body, in.Category, in.Visibility, in.AllowAnonymous, status, in.CommentsEnabled).
+		Scan(&pr.ID, &pr.AuthorID, &pr.Title, &pr.Body, &pr.Category, &pr.Visibility, &pr.Tradition, &pr.AllowAnonymous, &pr.Status, &pr.PrayedCount, &pr.CommentsEnabled, &pr.CreatedAt, &pr.UpdatedAt)
 	if err != nil {
 		return models.PrayerRequest{}, err
 	}
 
 	for _, groupID := range in.GroupIDs {
+		banned, err := isGroupBannedOn(ctx, tx, groupID, in.AuthorID)
+		if err != nil {
+			return models.PrayerRequest{}, err
+		}
+		if banned {
+			return models.PrayerRequest{}, ErrGroupBanned
+		}
 		var canPost bool
 		err = tx.QueryRow(ctx, `
 			SELECT EXISTS (
@@ -335,12 +442,47 @@ func (r *PostgresRepository) CreatePrayerRequest(ctx context.Context, in models.
 		}
 	}
 
+	if err = enqueueForReview(ctx, tx, pr.ID, in.ReviewGroupIDs, in.ReviewPublic, ""); err != nil {
+		return models.PrayerRequest{}, err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return models.PrayerRequest{}, err
 	}
 	return pr, nil
 }
 
+// enqueueForReview picks each scope's default reason when reason is empty.
+func enqueueForReview(ctx context.Context, exec notifyExec, requestID string, groupIDs []string, public bool, reason models.ModerationReason) error {
+	for _, groupID := range groupIDs {
+		id := groupID
+		itemReason := reason
+		if itemReason == "" {
+			itemReason = models.ReasonGroupModeration
+		}
+		if err := enqueueModerationOn(ctx, exec, models.EnqueueModerationInput{
+			PrayerRequestID: requestID,
+			GroupID:         &id,
+			Reason:          itemReason,
+		}); err != nil {
+			return err
+		}
+	}
+	if public {
+		itemReason := reason
+		if itemReason == "" {
+			itemReason = models.ReasonPublicModeration
+		}
+		if err := enqueueModerationOn(ctx, exec, models.EnqueueModerationInput{
+			PrayerRequestID: requestID,
+			Reason:          itemReason,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *PostgresRepository) UpdatePrayerRequest(ctx context.Context, in models.UpdatePrayerRequestInput) (models.PrayerRequest, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
@@ -348,24 +490,37 @@ func (r *PostgresRepository) UpdatePrayerRequest(ctx context.Context, in models.
 	}
 	defer tx.Rollback(ctx)
 
-	ct, err := tx.Exec(ctx, `
-		UPDATE prayer_requests
-		SET title = $3,
-			body = $4,
-			category = $5,
-			visibility = $6,
-			allow_anonymous = $7,
-			status = 'ACTIVE',
-			updated_at = NOW()
+	var currentStatus models.PrayerStatus
+	err = tx.QueryRow(ctx, `
+		SELECT status
+		FROM prayer_requests
 		WHERE id = $1
 		  AND author_id = $2
 		  AND deleted_at IS NULL
-	`, in.RequestID, in.EditorID, in.Title, in.Body, in.Category, in.Visibility, in.AllowAnonymous)
+		FOR UPDATE
+	`, in.RequestID, in.EditorID).Scan(&currentStatus)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PrayerRequest{}, ErrPrayerRequestNotFound
+		}
 		return models.PrayerRequest{}, err
 	}
-	if ct.RowsAffected() == 0 {
-		return models.PrayerRequest{}, ErrPrayerRequestNotFound
+
+	_, err = tx.Exec(ctx, `
+		UPDATE prayer_requests
+		SET title = $2,
+			body = $3,
+			category = $4,
+			visibility = $5,
+			allow_anonymous = $6,
+			comments_enabled = COALESCE($7::bool, comments_enabled),
+			updated_at = NOW(),
+			last_activity_at = NOW(),
+			archive_warned_at = NULL
+		WHERE id = $1
+	`, in.RequestID, in.Title, in.Body, in.Category, in.Visibility, in.AllowAnonymous, in.CommentsEnabled)
+	if err != nil {
+		return models.PrayerRequest{}, err
 	}
 
 	_, err = tx.Exec(ctx, `DELETE FROM prayer_request_groups WHERE prayer_request_id = $1`, in.RequestID)
@@ -374,6 +529,13 @@ func (r *PostgresRepository) UpdatePrayerRequest(ctx context.Context, in models.
 	}
 
 	for _, groupID := range in.GroupIDs {
+		banned, err := isGroupBannedOn(ctx, tx, groupID, in.EditorID)
+		if err != nil {
+			return models.PrayerRequest{}, err
+		}
+		if banned {
+			return models.PrayerRequest{}, ErrGroupBanned
+		}
 		var canPost bool
 		err = tx.QueryRow(ctx, `
 			SELECT EXISTS (
@@ -400,12 +562,19 @@ func (r *PostgresRepository) UpdatePrayerRequest(ctx context.Context, in models.
 		}
 	}
 
+	// Only live or in-review requests move with edits; others keep their status.
+	if currentStatus == models.StatusActive || currentStatus == models.StatusPendingReview {
+		if err = r.reenterReviewOnEdit(ctx, tx, in, currentStatus); err != nil {
+			return models.PrayerRequest{}, err
+		}
+	}
+
 	var pr models.PrayerRequest
 	err = tx.QueryRow(ctx, `
-		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, created_at, updated_at
+		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, comments_enabled, comment_count, resolution, testimony, closed_at, created_at, updated_at
 		FROM prayer_requests
 		WHERE id = $1
-	`, in.RequestID).Scan(&pr.ID, &pr.AuthorID, &pr.Title, &pr.Body, &pr.Category, &pr.Visibility, &pr.Tradition, &pr.AllowAnonymous, &pr.Status, &pr.PrayedCount, &pr.CreatedAt, &pr.UpdatedAt)
+	`, in.RequestID).Scan(&pr.ID, &pr.AuthorID, &pr.Title, &pr.Body, &pr.Category, &pr.Visibility, &pr.Tradition, &pr.AllowAnonymous, &pr.Status, &pr.PrayedCount, &pr.CommentsEnabled, &pr.CommentCount, &pr.Resolution, &pr.Testimony, &pr.ClosedAt, &pr.CreatedAt, &pr.UpdatedAt)
 	if err != nil {
 		return models.PrayerRequest{}, err
 	}
@@ -416,6 +585,62 @@ func (r *PostgresRepository) UpdatePrayerRequest(ctx context.Context, in models.
 	return pr, nil
 }
 
+// reenterReviewOnEdit queues an edit for every scope that still requires review.
+func (r *PostgresRepository) reenterReviewOnEdit(ctx context.Context, tx pgx.Tx, in models.UpdatePrayerRequestInput, currentStatus models.PrayerStatus) error {
+	scopes := append([]string{}, in.ReviewGroupIDs...)
+	if in.ReviewPublic {
+		scopes = append(scopes, "")
+	}
+	_, err := tx.Exec(ctx, `
+		UPDATE moderation_queue
+		SET status = 'RESOLVED', resolved_at = NOW(), updated_at = NOW()
+		WHERE prayer_request_id = $1
+		  AND status <> 'RESOLVED'
+		  AND reason = ANY($2::text[])
+		  AND NOT (COALESCE(group_id::text, '') = ANY($3::text[]))
+	`, in.RequestID, []string{
+		string(models.ReasonGroupModeration),
+		string(models.ReasonPublicModeration),
+		string(models.ReasonEdited),
+		string(models.ReasonReverted),
+	}, scopes)
+	if err != nil {
+		return err
+	}
+
+	if err = enqueueForReview(ctx, tx, in.RequestID, in.ReviewGroupIDs, in.ReviewPublic, models.ReasonEdited); err != nil {
+		return err
+	}
+
+	newStatus := models.StatusActive
+	if len(scopes) > 0 {
+		newStatus = models.StatusPendingReview
+	} else if currentStatus == models.StatusPendingReview {
+		var stillOpen bool
+		err = tx.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM moderation_queue
+				WHERE prayer_request_id = $1 AND status <> 'RESOLVED'
+			)
+		`, in.RequestID).Scan(&stillOpen)
+		if err != nil {
+			return err
+		}
+		if stillOpen {
+			newStatus = models.StatusPendingReview
+		}
+	}
+	if newStatus == currentStatus {
+		return nil
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE prayer_requests
+		SET status = $2
+		WHERE id = $1
+	`, in.RequestID, newStatus)
+	return err
+}
+
 func (r *PostgresRepository) DeletePrayerRequest(ctx context.Context, userID, requestID string) error {
 	ct, err := r.db.Exec(ctx, `
 		UPDATE prayer_requests
@@ -436,7 +661,7 @@ func (r *PostgresRepository) DeletePrayerRequest(ctx context.Context, userID, re
 func (r *PostgresRepository) GetPrayerRequestByID(ctx context.Context, userID, requestID string) (models.PrayerRequest, error) {
 	var pr models.PrayerRequest
 	err := r.db.QueryRow(ctx, `
-		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, created_at, updated_at
+		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, comments_enabled, comment_count, resolution, testimony, closed_at, created_at, updated_at
 		FROM prayer_requests
 		WHERE id = $1
 		  AND deleted_at IS NULL
@@ -446,10 +671,10 @@ func (r *PostgresRepository) GetPrayerRequestByID(ctx context.Context, userID, r
 		  )
 		  AND (
 			author_id = $2
-			OR (visibility = 'PUBLIC' AND status = 'ACTIVE')
+			OR (visibility = 'PUBLIC' AND status IN ('ACTIVE', 'CLOSED'))
 			OR (
 				visibility = 'GROUP_ONLY'
-				AND status = 'ACTIVE'
+				AND status IN ('ACTIVE', 'CLOSED')
 				AND EXISTS (
 					SELECT 1
 					FROM prayer_request_groups prg
@@ -460,7 +685,7 @@ func (r *PostgresRepository) GetPrayerRequestByID(ctx context.Context, userID, r
 				)
 			)
 		  )
-	`, requestID, userID).Scan(&pr.ID, &pr.AuthorID, &pr.Title, &pr.Body, &pr.Category, &pr.Visibility, &pr.Tradition, &pr.AllowAnonymous, &pr.Status, &pr.PrayedCount, &pr.CreatedAt, &pr.UpdatedAt)
+	`, requestID, userID).Scan(&pr.ID, &pr.AuthorID, &pr.Title, &pr.Body, &pr.Category, &pr.Visibility, &pr.Tradition, &pr.AllowAnonymous, &pr.Status, &pr.PrayedCount, &pr.CommentsEnabled, &pr.CommentCount, &pr.Resolution, &pr.Testimony, &pr.ClosedAt, &pr.CreatedAt, &pr.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.PrayerRequest{}, ErrPrayerRequestNotFound
@@ -472,21 +697,27 @@ func (r *PostgresRepository) GetPrayerRequestByID(ctx context.Context, userID, r
 	if err = r.enrichPrayerRequests(ctx, userID, items); err != nil {
 		return models.PrayerRequest{}, err
 	}
+	if items[0].LatestUpdate, err = r.getLatestPrayerUpdate(ctx, requestID); err != nil {
+		return models.PrayerRequest{}, err
+	}
 	return items[0], nil
 }
 
-func (r *PostgresRepository) ListPublicPrayerRequests(ctx context.Context, viewerUserID string, limit, offset int) ([]models.PrayerRequest, error) {
+func (r *PostgresRepository) ListPublicPrayerRequests(ctx context.Context, viewerUserID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error) {
+	after, afterID := feedCursorArgs(page.After)
 	rows, err := r.db.Query(ctx, `
-		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, created_at, updated_at
+		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, comments_enabled, comment_count, resolution, testimony, closed_at, created_at, updated_at
 		FROM prayer_requests
-		WHERE visibility = 'PUBLIC' AND status = 'ACTIVE' AND deleted_at IS NULL
+		WHERE visibility = 'PUBLIC' AND deleted_at IS NULL
 		  AND tradition = COALESCE(
 			(SELECT tradition FROM users WHERE id = NULLIF($3, '')::uuid AND deleted_at IS NULL),
 			prayer_requests.tradition
 		  )
-		ORDER BY created_at DESC
+		  AND ($4::timestamptz IS NULL OR (created_at, id) < ($4::timestamptz, $5::uuid))
+		  AND `+feedFilterSQL("prayer_requests", 6, false, filter)+`
+		ORDER BY created_at DESC, id DESC
 		LIMIT $1 OFFSET $2
-	`, limit, offset, viewerUserID)
+	`, append([]any{page.Limit, page.Offset, viewerUserID, after, afterID}, feedFilterArgs(viewerUserID, filter)...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -502,43 +733,62 @@ func (r *PostgresRepository) ListPublicPrayerRequests(ctx context.Context, viewe
 	return items, nil
 }
 
-func (r *PostgresRepository) CountPublicPrayerRequests(ctx context.Context, viewerUserID string) (int64, error) {
+func (r *PostgresRepository) CountPublicPrayerRequests(ctx context.Context, viewerUserID string, filter models.FeedFilter) (int64, error) {
 	var total int64
 	err := r.db.QueryRow(ctx, `
 		SELECT COUNT(*)::bigint
 		FROM prayer_requests
-		WHERE visibility = 'PUBLIC' AND status = 'ACTIVE' AND deleted_at IS NULL
+		WHERE visibility = 'PUBLIC' AND deleted_at IS NULL
 		  AND tradition = COALESCE(
 			(SELECT tradition FROM users WHERE id = NULLIF($1, '')::uuid AND deleted_at IS NULL),
 			prayer_requests.tradition
 		  )
-	`, viewerUserID).Scan(&total)
+		  AND `+feedFilterSQL("prayer_requests", 2, false, filter)+`
+	`, append([]any{viewerUserID}, feedFilterArgs(viewerUserID, filter)...)...).Scan(&total)
 	return total, err
 }
 
 func (r *PostgresRepository) RecordPrayerAction(ctx context.Context, userID, requestID string, actionType models.PrayerActionType, windowHours int) error {
+	// FOR SHARE waits for a concurrent close and then sees its status.
 	ct, err := r.db.Exec(ctx, `
 		INSERT INTO prayer_actions (user_id, prayer_request_id, action_type)
-		SELECT $1, $2, $3
-		WHERE NOT EXISTS (
+		SELECT $1, pr.id, $3
+		FROM prayer_requests pr
+		WHERE pr.id = $2
+		  AND pr.deleted_at IS NULL
+		  AND pr.status = 'ACTIVE'
+		  AND NOT EXISTS (
 			SELECT 1
 			FROM prayer_actions
 			WHERE user_id = $1
 			AND prayer_request_id = $2
 			AND action_type = $3
 			AND created_at > NOW() - ($4::text || ' hours')::interval
-		)
+		  )
+		FOR SHARE OF pr
 	`, userID, requestID, actionType, windowHours)
 	if err != nil {
 		return err
 	}
 	if ct.RowsAffected() == 0 {
+		var status models.PrayerStatus
+		err = r.db.QueryRow(ctx, `
+			SELECT status FROM prayer_requests WHERE id = $1 AND deleted_at IS NULL
+		`, requestID).Scan(&status)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return ErrPrayerRequestNotFound
+		case err != nil:
+			return err
+		case status != models.StatusActive:
+			return ErrPrayerRequestNotActive
+		}
 		return ErrDuplicatePrayedAction
 	}
 
 	_, err = r.db.Exec(ctx, `
 		UPDATE prayer_requests
-		SET prayed_count = prayed_count + 1, updated_at = NOW()
+		SET prayed_count = prayed_count + 1, updated_at = NOW(), last_activity_at = NOW(), archive_warned_at = NULL
 		WHERE id = $1 AND deleted_at IS NULL
 	`, requestID)
 	if err != nil {
@@ -549,34 +799,55 @@ func (r *PostgresRepository) RecordPrayerAction(ctx context.Context, userID, req
 	if err := r.db.QueryRow(ctx, `
 		SELECT author_id::text FROM prayer_requests WHERE id = $1 AND deleted_at IS NULL
 	`, requestID).Scan(&authorID); err == nil && authorID != "" && authorID != userID {
-		actor := userID
-		_ = insertNotificationOn(ctx, r.db, models.CreateNotificationInput{
-			UserID:      authorID,
-			Type:        models.NotificationTypePrayed,
-			ActorUserID: &actor,
-			SubjectType: models.NotificationSubjectPrayerRequest,
-			SubjectID:   requestID,
-			Payload:     map[string]any{"actionType": string(actionType)},
-		})
+		r.notifyPrayed(ctx, authorID, userID, requestID, actionType)
 	}
 
 	return nil
 }
 
-func (r *PostgresRepository) ListGroupsPrayerRequests(ctx context.Context, userID string, limit, offset int) ([]models.PrayerRequest, error) {
+// notifyPrayed holds mergePrayedNotification's lock until commit.
+func (r *PostgresRepository) notifyPrayed(ctx context.Context, authorID, userID, requestID string, actionType models.PrayerActionType) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback(ctx)
+	actor := userID
+	if err := dispatchNotification(ctx, tx, models.CreateNotificationInput{
+		UserID:      authorID,
+		Type:        models.NotificationTypePrayed,
+		ActorUserID: &actor,
+		SubjectType: models.NotificationSubjectPrayerRequest,
+		SubjectID:   requestID,
+		Payload: map[string]any{
+			"actionType":   string(actionType),
+			"count":        1,
+			"actionCounts": map[string]int{string(actionType): 1},
+			"actorIds":     []string{userID},
+			"othersCount":  0,
+		},
+	}); err != nil {
+		return
+	}
+	_ = tx.Commit(ctx)
+}
+
+func (r *PostgresRepository) ListGroupsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error) {
+	after, afterID := feedCursorArgs(page.After)
 	rows, err := r.db.Query(ctx, `
-		SELECT DISTINCT pr.id::text, pr.author_id::text, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.created_at, pr.updated_at
+		SELECT DISTINCT pr.id::text, pr.author_id::text, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.comments_enabled, pr.comment_count, pr.resolution, pr.testimony, pr.closed_at, pr.created_at, pr.updated_at
 		FROM prayer_requests pr
 		INNER JOIN prayer_request_groups prg ON prg.prayer_request_id = pr.id
 		INNER JOIN group_memberships gm ON gm.group_id = prg.group_id
 		WHERE gm.user_id = $1
 			AND gm.deleted_at IS NULL
-			AND pr.status = 'ACTIVE'
 			AND pr.deleted_at IS NULL
 			AND pr.tradition = (SELECT tradition FROM users WHERE id = $1 AND deleted_at IS NULL)
-		ORDER BY pr.created_at DESC
+			AND ($4::timestamptz IS NULL OR (pr.created_at, pr.id) < ($4::timestamptz, $5::uuid))
+			AND `+feedFilterSQL("pr", 6, false, filter)+`
+		ORDER BY pr.created_at DESC, pr.id DESC
 		LIMIT $2 OFFSET $3
-	`, userID, limit, offset)
+	`, append([]any{userID, page.Limit, page.Offset, after, afterID}, feedFilterArgs(userID, filter)...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -591,7 +862,7 @@ func (r *PostgresRepository) ListGroupsPrayerRequests(ctx context.Context, userI
 	return items, nil
 }
 
-func (r *PostgresRepository) CountGroupsPrayerRequests(ctx context.Context, userID string) (int64, error) {
+func (r *PostgresRepository) CountGroupsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter) (int64, error) {
 	var total int64
 	err := r.db.QueryRow(ctx, `
 		SELECT COUNT(*)::bigint
@@ -602,31 +873,33 @@ func (r *PostgresRepository) CountGroupsPrayerRequests(ctx context.Context, user
 			INNER JOIN group_memberships gm ON gm.group_id = prg.group_id
 			WHERE gm.user_id = $1
 			  AND gm.deleted_at IS NULL
-			  AND pr.status = 'ACTIVE'
 			  AND pr.deleted_at IS NULL
 			  AND pr.tradition = (SELECT tradition FROM users WHERE id = $1 AND deleted_at IS NULL)
+			  AND `+feedFilterSQL("pr", 2, false, filter)+`
 		) AS group_requests
-	`, userID).Scan(&total)
+	`, append([]any{userID}, feedFilterArgs(userID, filter)...)...).Scan(&total)
 	return total, err
 }
 
-func (r *PostgresRepository) ListFriendsPrayerRequests(ctx context.Context, userID string, limit, offset int) ([]models.PrayerRequest, error) {
+func (r *PostgresRepository) ListFriendsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error) {
+	after, afterID := feedCursorArgs(page.After)
 	rows, err := r.db.Query(ctx, `
 		WITH friend_ids AS (
 			SELECT CASE WHEN user_id = $1 THEN friend_user_id ELSE user_id END AS friend_id
 			FROM friendships
 			WHERE status = 'ACCEPTED' AND (user_id = $1 OR friend_user_id = $1)
 		)
-		SELECT pr.id::text, pr.author_id::text, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.created_at, pr.updated_at
+		SELECT pr.id::text, pr.author_id::text, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.comments_enabled, pr.comment_count, pr.resolution, pr.testimony, pr.closed_at, pr.created_at, pr.updated_at
 		FROM prayer_requests pr
 		INNER JOIN friend_ids f ON f.friend_id = pr.author_id
 		WHERE pr.visibility = 'PUBLIC'
-			AND pr.status = 'ACTIVE'
 			AND pr.deleted_at IS NULL
 			AND pr.tradition = (SELECT tradition FROM users WHERE id = $1 AND deleted_at IS NULL)
-		ORDER BY pr.created_at DESC
+			AND ($4::timestamptz IS NULL OR (pr.created_at, pr.id) < ($4::timestamptz, $5::uuid))
+			AND `+feedFilterSQL("pr", 6, false, filter)+`
+		ORDER BY pr.created_at DESC, pr.id DESC
 		LIMIT $2 OFFSET $3
-	`, userID, limit, offset)
+	`, append([]any{userID, page.Limit, page.Offset, after, afterID}, feedFilterArgs(userID, filter)...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -641,7 +914,7 @@ func (r *PostgresRepository) ListFriendsPrayerRequests(ctx context.Context, user
 	return items, nil
 }
 
-func (r *PostgresRepository) CountFriendsPrayerRequests(ctx context.Context, userID string) (int64, error) {
+func (r *PostgresRepository) CountFriendsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter) (int64, error) {
 	var total int64
 	err := r.db.QueryRow(ctx, `
 		WITH friend_ids AS (
@@ -653,14 +926,15 @@ func (r *PostgresRepository) CountFriendsPrayerRequests(ctx context.Context, use
 		FROM prayer_requests pr
 		INNER JOIN friend_ids f ON f.friend_id = pr.author_id
 		WHERE pr.visibility = 'PUBLIC'
-		  AND pr.status = 'ACTIVE'
 		  AND pr.deleted_at IS NULL
 		  AND pr.tradition = (SELECT tradition FROM users WHERE id = $1 AND deleted_at IS NULL)
-	`, userID).Scan(&total)
+		  AND `+feedFilterSQL("pr", 2, false, filter)+`
+	`, append([]any{userID}, feedFilterArgs(userID, filter)...)...).Scan(&total)
 	return total, err
 }
 
-func (r *PostgresRepository) ListHomePrayerRequests(ctx context.Context, userID string, limit, offset int) ([]models.PrayerRequest, error) {
+func (r *PostgresRepository) ListHomePrayerRequests(ctx context.Context, userID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error) {
+	after, afterID := feedCursorArgs(page.After)
 	rows, err := r.db.Query(ctx, `
 		WITH viewer AS (
 			SELECT tradition FROM users WHERE id = $1 AND deleted_at IS NULL
@@ -671,30 +945,33 @@ func (r *PostgresRepository) ListHomePrayerRequests(ctx context.Context, userID
 			WHERE status = 'ACCEPTED' AND (user_id = $1 OR friend_user_id = $1)
 		),
 		home_requests AS (
-			SELECT pr.id, pr.author_id, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.created_at, pr.updated_at
+			SELECT pr.id, pr.author_id, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.comments_enabled, pr.comment_count, pr.resolution, pr.testimony, pr.closed_at, pr.created_at, pr.updated_at
 			FROM prayer_requests pr
 			WHERE pr.author_id = $1
-			  AND pr.status IN ('ACTIVE', 'PENDING_REVIEW')
 			  AND pr.deleted_at IS NULL
+			  AND `+feedFilterSQL("pr", 6, true, filter)+`
 			UNION
-			SELECT pr.id, pr.author_id, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.created_at, pr.updated_at
+			SELECT pr.id, pr.author_id, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.comments_enabled, pr.comment_count, pr.resolution, pr.testimony, pr.closed_at, pr.created_at, pr.updated_at
 			FROM prayer_requests pr
 			INNER JOIN friend_ids f ON f.friend_id = pr.author_id
-			WHERE pr.visibility = 'PUBLIC' AND pr.status = 'ACTIVE' AND pr.deleted_at IS NULL
+			WHERE pr.visibility = 'PUBLIC' AND pr.deleted_at IS NULL
 			  AND pr.tradition = (SELECT tradition FROM viewer)
+			  AND `+feedFilterSQL("pr", 6, false, filter)+`
 			UNION
-			SELECT pr.id, pr.author_id, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.created_at, pr.updated_at
+			SELECT pr.id, pr.author_id, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.comments_enabled, pr.comment_count, pr.resolution, pr.testimony, pr.closed_at, pr.created_at, pr.updated_at
 			FROM prayer_requests pr
 			INNER JOIN prayer_request_groups prg ON prg.prayer_request_id = pr.id
 			INNER JOIN group_memberships gm ON gm.group_id = prg.group_id
-			WHERE gm.user_id = $1 AND gm.deleted_at IS NULL AND pr.status = 'ACTIVE' AND pr.deleted_at IS NULL
+			WHERE gm.user_id = $1 AND gm.deleted_at IS NULL AND pr.deleted_at IS NULL
 			  AND pr.tradition = (SELECT tradition FROM viewer)
+			  AND `+feedFilterSQL("pr", 6, false, filter)+`
 		)
-		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, created_at, updated_at
+		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, comments_enabled, comment_count, resolution, testimony, closed_at, created_at, updated_at
 		FROM home_requests
-		ORDER BY created_at DESC
+		WHERE ($4::timestamptz IS NULL OR (created_at, id) < ($4::timestamptz, $5::uuid))
+		ORDER BY created_at DESC, id DESC
 		LIMIT $2 OFFSET $3
-	`, userID, limit, offset)
+	`, append([]any{userID, page.Limit, page.Offset, after, afterID}, feedFilterArgs(userID, filter)...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -709,7 +986,7 @@ func (r *PostgresRepository) ListHomePrayerRequests(ctx context.Context, userID
 	return items, nil
 }
 
-func (r *PostgresRepository) CountHomePrayerRequests(ctx context.Context, userID string) (int64, error) {
+func (r *PostgresRepository) CountHomePrayerRequests(ctx context.Context, userID string, filter models.FeedFilter) (int64, error) {
 	var total int64
 	err := r.db.QueryRow(ctx, `
 		WITH viewer AS (
@@ -724,25 +1001,27 @@ func (r *PostgresRepository) CountHomePrayerRequests(ctx context.Context, userID
 			SELECT pr.id
 			FROM prayer_requests pr
 			WHERE pr.author_id = $1
-			  AND pr.status IN ('ACTIVE', 'PENDING_REVIEW')
 			  AND pr.deleted_at IS NULL
+			  AND `+feedFilterSQL("pr", 2, true, filter)+`
 			UNION
 			SELECT pr.id
 			FROM prayer_requests pr
 			INNER JOIN friend_ids f ON f.friend_id = pr.author_id
-			WHERE pr.visibility = 'PUBLIC' AND pr.status = 'ACTIVE' AND pr.deleted_at IS NULL
+			WHERE pr.visibility = 'PUBLIC' AND pr.deleted_at IS NULL
 			  AND pr.tradition = (SELECT tradition FROM viewer)
+			  AND `+feedFilterSQL("pr", 2, false, filter)+`
 			UNION
 			SELECT pr.id
 			FROM prayer_requests pr
 			INNER JOIN prayer_request_groups prg ON prg.prayer_request_id = pr.id
 			INNER JOIN group_memberships gm ON gm.group_id = prg.group_id
-			WHERE gm.user_id = $1 AND gm.deleted_at IS NULL AND pr.status = 'ACTIVE' AND pr.deleted_at IS NULL
+			WHERE gm.user_id = $1 AND gm.deleted_at IS NULL AND pr.deleted_at IS NULL
 			  AND pr.tradition = (SELECT tradition FROM viewer)
+			  AND `+feedFilterSQL("pr", 2, false, filter)+`
 		)
 		SELECT COUNT(*)::bigint
 		FROM home_requests
-	`, userID).Scan(&total)
+	`, append([]any{userID}, feedFilterArgs(userID, filter)...)...).Scan(&total)
 	return total, err
 }
 
@@ -854,6 +1133,13 @@ func (r *PostgresRepository) RequestJoinGroup(ctx context.Context, userID, group
 	if err != nil {
 		return err
 	}
+	banned, err := isGroupBannedOn(ctx, r.db, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return ErrGroupBanned
+	}
 
 	switch joinPolicy {
 	case models.JoinPolicyOpen:
@@ -966,6 +1252,14 @@ func (r *PostgresRepository) ApproveGroupJoinRequest(ctx context.Context, actorU
 		return err
 	}
 
+	banned, err := isGroupBannedOn(ctx, tx, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return ErrGroupBanned
+	}
+
 	_, err = tx.Exec(ctx, `
 		INSERT INTO group_memberships (group_id, user_id, role)
 		VALUES ($1, $2, 'MEMBER')
@@ -976,7 +1270,7 @@ func (r *PostgresRepository) ApproveGroupJoinRequest(ctx context.Context, actorU
 	}
 
 	actor := actorUserID
-	_ = insertNotificationOn(ctx, tx, models.CreateNotificationInput{
+	_ = dispatchNotification(ctx, tx, models.CreateNotificationInput{
 		UserID:      userID,
 		Type:        models.NotificationTypeGroupJoinApproved,
 		ActorUserID: &actor,
@@ -1002,11 +1296,18 @@ func (r *PostgresRepository) isGroupAdmin(ctx context.Context, userID, groupID s
 	return exists, err
 }
 
+func feedCursorArgs(c *models.FeedCursor) (*time.Time, *string) {
+	if c == nil {
+		return nil, nil
+	}
+	return &c.CreatedAt, &c.ID
+}
+
 func scanPrayerRequests(rows pgx.Rows) ([]models.PrayerRequest, error) {
 	items := make([]models.PrayerRequest, 0)
 	for rows.Next() {
 		var pr models.PrayerRequest
-		err := rows.Scan(&pr.ID, &pr.AuthorID, &pr.Title, &pr.Body, &pr.Category, &pr.Visibility, &pr.Tradition, &pr.AllowAnonymous, &pr.Status, &pr.PrayedCount, &pr.CreatedAt, &pr.UpdatedAt)
+		err := rows.Scan(&pr.ID, &pr.AuthorID, &pr.Title, &pr.Body, &pr.Category, &pr.Visibility, &pr.Tradition, &pr.AllowAnonymous, &pr.Status, &pr.PrayedCount, &pr.CommentsEnabled, &pr.CommentCount, &pr.Resolution, &pr.Testimony, &pr.ClosedAt, &pr.CreatedAt, &pr.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -1160,7 +1461,10 @@ func (r *PostgresRepository) enrichPrayerRequests(ctx context.Context, userID st
 		}
 		items[idx].MyPrayerTypes = append(items[idx].MyPrayerTypes, actionType)
 	}
-	return userRows.Err()
+	if err = userRows.Err(); err != nil {
+		return err
+	}
+	return r.attachReviewState(ctx, userID, items)
 }
 
 func (r *PostgresRepository) SendFriendRequest(ctx context.Context, fromUserID, targetUsername string) error {
@@ -1195,12 +1499,35 @@ func (r *PostgresRepository) SendFriendRequest(ctx context.Context, fromUserID,
 		LIMIT 1
 	`, fromUserID, targetUserID).Scan(&existingStatus)
 	if err == nil {
+		// The sender is not told they were blocked.
+		if existingStatus == "BLOCKED" {
+			return ErrFriendUserNotFound
+		}
 		return ErrFriendRequestAlreadyExists
 	}
 	if !errors.Is(err, pgx.ErrNoRows) {
 		return err
 	}
 
+	// The target's privacy settings decide who may ask.
+	var allowed bool
+	err = tx.QueryRow(ctx, `
+		SELECT CASE COALESCE(ps.friend_requests, $3::text)
+		           WHEN 'EVERYONE' THEN TRUE
+		           WHEN 'FRIENDS_OF_FRIENDS' THEN `+mutualFriendSQL("$1::uuid", "$2::uuid")+`
+		           ELSE FALSE
+		       END
+		FROM users u
+		LEFT JOIN privacy_settings ps ON ps.user_id = u.id
+		WHERE u.id = $2
+	`, fromUserID, targetUserID, string(models.DefaultPrivacySettings.FriendRequests)).Scan(&allowed)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrFriendRequestsNotAllowed
+	}
+
 	var friendshipID string
 	err = tx.QueryRow(ctx, `
 		INSERT INTO friendships (user_id, friend_user_id, status)
@@ -1302,7 +1629,7 @@ func (r *PostgresRepository) AcceptFriendRequest(ctx context.Context, userID, re
 	}
 
 	actor := userID
-	_ = insertNotificationOn(ctx, r.db, models.CreateNotificationInput{
+	_ = dispatchNotification(ctx, r.db, models.CreateNotificationInput{
 		UserID:      senderID,
 		Type:        models.NotificationTypeFriendRequestAccepted,
 		ActorUserID: &actor,
@@ -1326,6 +1653,7 @@ func (r *PostgresRepository) SearchUsersForFriendship(ctx context.Context, userI
 			  u.username ILIKE $2 || '%'
 			  OR u.display_name ILIKE '%' || $2 || '%'
 		  )
+		  AND COALESCE((SELECT ps.searchable FROM privacy_settings ps WHERE ps.user_id = u.id), $4::boolean)
 		  AND NOT EXISTS (
 			  SELECT 1
 			  FROM friendships f
@@ -1334,7 +1662,7 @@ func (r *PostgresRepository) SearchUsersForFriendship(ctx context.Context, userI
 		  )
 		ORDER BY u.display_name ASC
 		LIMIT $3
-	`, userID, query, limit)
+	`, userID, query, limit, models.DefaultPrivacySettings.Searchable)
 	if err != nil {
 		return nil, err
 	}
@@ -1413,7 +1741,8 @@ func (r *PostgresRepository) CountGroupAdmins(ctx context.Context, groupID strin
 	return n, err
 }
 
-func (r *PostgresRepository) ListGroupMembers(ctx context.Context, groupID string, limit, offset int) ([]models.GroupMember, int64, error) {
+// ListGroupMembers leaves out anyone blocked either way.
+func (r *PostgresRepository) ListGroupMembers(ctx context.Context, viewerUserID, groupID string, limit, offset int) ([]models.GroupMember, int64, error) {
 	if limit <= 0 || limit > 200 {
 		limit = 50
 	}
@@ -1422,9 +1751,10 @@ func (r *PostgresRepository) ListGroupMembers(ctx context.Context, groupID strin
 	}
 	var total int64
 	err := r.db.QueryRow(ctx, `
-		SELECT COUNT(*)::bigint FROM group_memberships
-		WHERE group_id = $1 AND deleted_at IS NULL
-	`, groupID).Scan(&total)
+		SELECT COUNT(*)::bigint FROM group_memberships gm
+		WHERE gm.group_id = $1 AND gm.deleted_at IS NULL
+		  AND NOT `+blockedBetweenSQL("$2::uuid", "gm.user_id")+`
+	`, groupID, viewerUserID).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -1434,11 +1764,12 @@ func (r *PostgresRepository) ListGroupMembers(ctx context.Context, groupID strin
 		FROM group_memberships gm
 		INNER JOIN users u ON u.id = gm.user_id
 		WHERE gm.group_id = $1 AND gm.deleted_at IS NULL AND u.deleted_at IS NULL
+		  AND NOT `+blockedBetweenSQL("$4::uuid", "gm.user_id")+`
 		ORDER BY
 			CASE gm.role WHEN 'ADMIN' THEN 0 WHEN 'MODERATOR' THEN 1 ELSE 2 END,
 			gm.created_at ASC
 		LIMIT $2 OFFSET $3
-	`, groupID, limit, offset)
+	`, groupID, limit, offset, viewerUserID)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -1496,6 +1827,7 @@ func (r *PostgresRepository) UpdateGroup(ctx context.Context, groupID string, in
 			description = COALESCE($3, description),
 			image_url = CASE WHEN $4::text IS NULL THEN image_url ELSE NULLIF($4, '') END,
 			join_policy = COALESCE($5::group_join_policy, join_policy),
+			requires_moderation = COALESCE($6::bool, requires_moderation),
 			updated_at = NOW()
 		WHERE id = $1 AND deleted_at IS NULL
 		RETURNING id::text, name, description, image_url, join_policy, requires_moderation, created_by::text, created_at, updated_at
@@ -1505,6 +1837,7 @@ func (r *PostgresRepository) UpdateGroup(ctx context.Context, groupID string, in
 		in.Description,
 		nullableStringPtr(in.ImageURL),
 		nullableJoinPolicyPtr(in.JoinPolicy),
+		in.RequiresModeration,
 	).Scan(&g.ID, &g.Name, &g.Description, &g.ImageURL, &g.JoinPolicy, &g.RequiresModeration, &g.CreatedBy, &g.CreatedAt, &g.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -1529,21 +1862,23 @@ func nullableJoinPolicyPtr(p *models.GroupJoinPolicy) any {
 	return string(*p)
 }
 
-func (r *PostgresRepository) ListPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string, limit, offset int) ([]models.PrayerRequest, error) {
+func (r *PostgresRepository) ListPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error) {
+	after, afterID := feedCursorArgs(page.After)
 	rows, err := r.db.Query(ctx, `
-		SELECT pr.id::text, pr.author_id::text, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.created_at, pr.updated_at
+		SELECT pr.id::text, pr.author_id::text, pr.title, pr.body, pr.category, pr.visibility, pr.tradition, pr.allow_anonymous, pr.status, pr.prayed_count, pr.comments_enabled, pr.comment_count, pr.resolution, pr.testimony, pr.closed_at, pr.created_at, pr.updated_at
 		FROM prayer_requests pr
 		INNER JOIN prayer_request_groups prg ON prg.prayer_request_id = pr.id
 		WHERE prg.group_id = $1
-		  AND pr.status = 'ACTIVE'
 		  AND pr.deleted_at IS NULL
 		  AND pr.tradition = COALESCE(
 			(SELECT tradition FROM users WHERE id = NULLIF($4, '')::uuid AND deleted_at IS NULL),
 			pr.tradition
 		  )
-		ORDER BY pr.created_at DESC
+		  AND ($5::timestamptz IS NULL OR (pr.created_at, pr.id) < ($5::timestamptz, $6::uuid))
+		  AND `+feedFilterSQL("pr", 7, false, filter)+`
+		ORDER BY pr.created_at DESC, pr.id DESC
 		LIMIT $2 OFFSET $3
-	`, groupID, limit, offset, viewerUserID)
+	`, append([]any{groupID, page.Limit, page.Offset, viewerUserID, after, afterID}, feedFilterArgs(viewerUserID, filter)...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -1558,67 +1893,23 @@ func (r *PostgresRepository) ListPrayerRequestsByGroup(ctx context.Context, view
 	return items, nil
 }
 
-func (r *PostgresRepository) CountPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string) (int64, error) {
+func (r *PostgresRepository) CountPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string, filter models.FeedFilter) (int64, error) {
 	var total int64
 	err := r.db.QueryRow(ctx, `
 		SELECT COUNT(*)::bigint
 		FROM prayer_requests pr
 		INNER JOIN prayer_request_groups prg ON prg.prayer_request_id = pr.id
 		WHERE prg.group_id = $1
-		  AND pr.status = 'ACTIVE'
 		  AND pr.deleted_at IS NULL
 		  AND pr.tradition = COALESCE(
 			(SELECT tradition FROM users WHERE id = NULLIF($2, '')::uuid AND deleted_at IS NULL),
 			pr.tradition
 		  )
-	`, groupID, viewerUserID).Scan(&total)
+		  AND `+feedFilterSQL("pr", 3, false, filter)+`
+	`, append([]any{groupID, viewerUserID}, feedFilterArgs(viewerUserID, filter)...)...).Scan(&total)
 	return total, err
 }
 
-type notifyExec interface {
-	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
-}
-
-func insertNotificationOn(ctx context.Context, exec notifyExec, in models.CreateNotificationInput) error {
-	payload := in.Payload
-	if payload == nil {
-		payload = map[string]any{}
-	}
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		log.Printf("notification insert failed: payload marshal type=%s user=%s err=%v", in.Type, in.UserID, err)
-		return err
-	}
-	_, err = exec.Exec(ctx, `
-		INSERT INTO notifications (user_id, type, actor_user_id, subject_type, subject_id, payload)
-		VALUES ($1::uuid, $2, NULLIF($3, '')::uuid, $4, $5::uuid, $6::jsonb)
-	`, in.UserID, string(in.Type), nullableStringValue(in.ActorUserID), string(in.SubjectType), in.SubjectID, string(payloadBytes))
-	if err != nil {
-		log.Printf("notification insert failed: type=%s user=%s subject_type=%s subject_id=%s err=%v", in.Type, in.UserID, in.SubjectType, in.SubjectID, err)
-	}
-	return err
-}
-
-// insertNotificationInTx inserts a notification inside a SAVEPOINT so a failure
-// (FK violation, type mismatch, etc.) doesn't poison the outer transaction.
-// Notifications are best-effort: callers ignore the error.
-func insertNotificationInTx(ctx context.Context, tx pgx.Tx, in models.CreateNotificationInput) error {
-	sub, err := tx.Begin(ctx)
-	if err != nil {
-		log.Printf("notification insert failed: in_tx savepoint begin type=%s user=%s err=%v", in.Type, in.UserID, err)
-		return err
-	}
-	if err := insertNotificationOn(ctx, sub, in); err != nil {
-		_ = sub.Rollback(ctx)
-		return err
-	}
-	if err := sub.Commit(ctx); err != nil {
-		log.Printf("notification insert failed: in_tx savepoint commit type=%s user=%s err=%v", in.Type, in.UserID, err)
-		return err
-	}
-	return nil
-}
-
 func nullableStringValue(p *string) string {
 	if p == nil {
 		return ""
@@ -1627,10 +1918,10 @@ func nullableStringValue(p *string) string {
 }
 
 func (r *PostgresRepository) CreateNotification(ctx context.Context, in models.CreateNotificationInput) error {
-	return insertNotificationOn(ctx, r.db, in)
+	return dispatchNotification(ctx, r.db, in)
 }
 
-func (r *PostgresRepository) ListNotifications(ctx context.Context, userID string, limit, offset int) ([]models.NotificationView, error) {
+func (r *PostgresRepository) ListNotifications(ctx context.Context, userID string, filter models.NotificationFilter, limit, offset int) ([]models.NotificationView, error) {
 	if limit <= 0 || limit > 50 {
 		limit = 20
 	}
@@ -1639,18 +1930,39 @@ func (r *PostgresRepository) ListNotifications(ctx context.Context, userID strin
 	}
 	rows, err := r.db.Query(ctx, `
 		SELECT n.id::text, n.type, n.subject_type, n.subject_id::text, n.payload, n.read_at, n.created_at,
-		       a.id::text, a.username, a.display_name, a.avatar_url
+		       a.id::text, a.username, a.display_name, a.avatar_url, `+notificationActorsSQL+`
 		FROM notifications n
 		LEFT JOIN users a ON a.id = n.actor_user_id AND a.deleted_at IS NULL
-		WHERE n.user_id = $1
+		WHERE n.user_id = $1 AND n.in_app
+		  AND ($4::text[] IS NULL OR n.type = ANY($4::text[]))
+		  AND (NOT $5 OR n.read_at IS NULL)
 		ORDER BY n.created_at DESC
 		LIMIT $2 OFFSET $3
-	`, userID, limit, offset)
+	`, userID, limit, offset, notificationTypeArg(filter.Types), filter.UnreadOnly)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	return scanNotificationViews(rows)
+}
+
+func derefStr(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+var notificationActorsSQL = `(
+	SELECT jsonb_agg(jsonb_build_object('userId', s.id::text, 'username', s.username,
+	                                    'displayName', s.display_name, 'avatarUrl', s.avatar_url) ORDER BY e.pos)
+	FROM jsonb_array_elements_text(CASE WHEN jsonb_typeof(n.payload->'actorIds') = 'array' THEN n.payload->'actorIds' ELSE '[]'::jsonb END)
+	     WITH ORDINALITY e(actor_id, pos)
+	INNER JOIN users s ON s.id::text = e.actor_id AND s.deleted_at IS NULL
+	WHERE NOT ` + blockedBetweenSQL("n.user_id", "s.id") + `
+)`
 
+func scanNotificationViews(rows pgx.Rows) ([]models.NotificationView, error) {
 	items := make([]models.NotificationView, 0)
 	for rows.Next() {
 		var (
@@ -1660,13 +1972,19 @@ func (r *PostgresRepository) ListNotifications(ctx context.Context, userID strin
 			actorUsername  *string
 			actorDisplay   *string
 			actorAvatarURL *string
+			actorsBytes    []byte
 		)
-		if err = rows.Scan(&n.ID, &n.Type, &n.SubjectType, &n.SubjectID, &payloadBytes, &n.ReadAt, &n.CreatedAt,
-			&actorID, &actorUsername, &actorDisplay, &actorAvatarURL); err != nil {
+		if err := rows.Scan(&n.ID, &n.Type, &n.SubjectType, &n.SubjectID, &payloadBytes, &n.ReadAt, &n.CreatedAt,
+			&actorID, &actorUsername, &actorDisplay, &actorAvatarURL, &actorsBytes); err != nil {
 			return nil, err
 		}
+		if len(actorsBytes) > 0 {
+			if err := json.Unmarshal(actorsBytes, &n.Actors); err != nil {
+				return nil, err
+			}
+		}
 		if len(payloadBytes) > 0 {
-			if err = json.Unmarshal(payloadBytes, &n.Payload); err != nil {
+			if err := json.Unmarshal(payloadBytes, &n.Payload); err != nil {
 				return nil, err
 			}
 		}
@@ -1686,30 +2004,27 @@ func (r *PostgresRepository) ListNotifications(ctx context.Context, userID strin
 	return items, rows.Err()
 }
 
-func derefStr(p *string) string {
-	if p == nil {
-		return ""
-	}
-	return *p
-}
-
 func (r *PostgresRepository) CountUnreadNotifications(ctx context.Context, userID string) (int64, error) {
 	var n int64
 	err := r.db.QueryRow(ctx, `
 		SELECT COUNT(*)::bigint
 		FROM notifications
-		WHERE user_id = $1 AND read_at IS NULL
+		WHERE user_id = $1 AND in_app AND read_at IS NULL
 	`, userID).Scan(&n)
 	return n, err
 }
 
 func (r *PostgresRepository) MarkNotificationRead(ctx context.Context, userID, id string) error {
-	_, err := r.db.Exec(ctx, `
+	ct, err := r.db.Exec(ctx, `
 		UPDATE notifications
 		SET read_at = NOW()
-		WHERE id = $1 AND user_id = $2 AND read_at IS NULL
+		WHERE id = $1 AND user_id = $2 AND in_app AND read_at IS NULL
 	`, id, userID)
-	return err
+	if err != nil || ct.RowsAffected() == 0 {
+		return err
+	}
+	signalNotificationsRead(ctx, r.db, userID)
+	return nil
 }
 
 func (r *PostgresRepository) notifyGroupAdminsOfJoinRequest(ctx context.Context, requesterUserID, groupID string) {
@@ -1732,7 +2047,7 @@ func (r *PostgresRepository) notifyGroupAdminsOfJoinRequest(ctx context.Context,
 		if adminID == requesterUserID {
 			continue
 		}
-		_ = insertNotificationOn(ctx, r.db, models.CreateNotificationInput{
+		_ = dispatchNotification(ctx, r.db, models.CreateNotificationInput{
 			UserID:      adminID,
 			Type:        models.NotificationTypeGroupJoinRequested,
 			ActorUserID: &actor,
@@ -1744,12 +2059,16 @@ func (r *PostgresRepository) notifyGroupAdminsOfJoinRequest(ctx context.Context,
 }
 
 func (r *PostgresRepository) MarkAllNotificationsRead(ctx context.Context, userID string) error {
-	_, err := r.db.Exec(ctx, `
+	ct, err := r.db.Exec(ctx, `
 		UPDATE notifications
 		SET read_at = NOW()
-		WHERE user_id = $1 AND read_at IS NULL
+		WHERE user_id = $1 AND in_app AND read_at IS NULL
 	`, userID)
-	return err
+	if err != nil || ct.RowsAffected() == 0 {
+		return err
+	}
+	signalNotificationsRead(ctx, r.db, userID)
+	return nil
 }
 
 var _ Repository = (*PostgresRepository)(nil)
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+
+	"parish-viva/backend/internal/models"
+)
+
+// searchMatchesSQL takes $1 viewer id, $2 query text, then the feed filter.
+func searchMatchesSQL(filter models.FeedFilter) string {
+	return `
+	WITH viewer AS (
+		SELECT tradition FROM users WHERE id = NULLIF($1, '')::uuid AND deleted_at IS NULL
+	),
+	q AS (
+		SELECT websearch_to_tsquery('portuguese_unaccent', $2) AS query
+	),
+	matches AS (
+		SELECT pr.*, ts_rank_cd(pr.search_vector, q.query) AS rank, q.query
+		FROM prayer_requests pr, q
+		WHERE pr.search_vector @@ q.query
+		  AND pr.deleted_at IS NULL
+		  AND (
+			(pr.author_id = NULLIF($1, '')::uuid AND ` + feedFilterSQL("pr", 3, true, filter) + `)
+			OR (
+				pr.tradition = COALESCE((SELECT tradition FROM viewer), pr.tradition)
+				AND (
+					pr.visibility = 'PUBLIC'
+					OR EXISTS (
+						SELECT 1
+						FROM prayer_request_groups prg
+						INNER JOIN group_memberships gm ON gm.group_id = prg.group_id
+						WHERE prg.prayer_request_id = pr.id
+						  AND gm.user_id = NULLIF($1, '')::uuid
+						  AND gm.deleted_at IS NULL
+					)
+				)
+				AND ` + feedFilterSQL("pr", 3, false, filter) + `
+			)
+		  )
+	)
+`
+}
+
+// searchHeadlineOptions marks matches in text that is already escaped.
+const searchHeadlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+
+func (r *PostgresRepository) SearchPrayerRequests(ctx context.Context, in models.SearchPrayerRequestsInput) ([]models.PrayerRequestSearchHit, int64, error) {
+	args := append([]any{in.ViewerID, in.Query}, feedFilterArgs(in.ViewerID, in.Filter)...)
+
+	var total int64
+	err := r.db.QueryRow(ctx, searchMatchesSQL(in.Filter)+`
+		SELECT COUNT(*)::bigint FROM matches
+	`, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, searchMatchesSQL(in.Filter)+`
+		SELECT id::text, author_id::text, title, body, category, visibility, tradition, allow_anonymous, status, prayed_count, comments_enabled, comment_count, resolution, testimony, closed_at, created_at, updated_at,
+			rank,
+			ts_headline('portuguese_unaccent', replace(replace(replace(title, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), query, 'HighlightAll=true'),
+			ts_headline('portuguese_unaccent', replace(replace(replace(body, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), query, '`+searchHeadlineOptions+`')
+		FROM matches
+		ORDER BY rank DESC, created_at DESC, id DESC
+		LIMIT $10 OFFSET $11
+	`, append(args, in.Limit, in.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	hits := make([]models.PrayerRequestSearchHit, 0)
+	for rows.Next() {
+		var h models.PrayerRequestSearchHit
+		pr := &h.PrayerRequest
+		err = rows.Scan(&pr.ID, &pr.AuthorID, &pr.Title, &pr.Body, &pr.Category, &pr.Visibility, &pr.Tradition, &pr.AllowAnonymous, &pr.Status, &pr.PrayedCount, &pr.CommentsEnabled, &pr.CommentCount, &pr.Resolution, &pr.Testimony, &pr.ClosedAt, &pr.CreatedAt, &pr.UpdatedAt,
+			&h.Rank, &h.TitleHighlight, &h.Snippet)
+		if err != nil {
+			return nil, 0, err
+		}
+		hits = append(hits, h)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]models.PrayerRequest, len(hits))
+	for i := range hits {
+		items[i] = hits[i].PrayerRequest
+	}
+	if err = r.enrichPrayerRequests(ctx, in.ViewerID, items); err != nil {
+		return nil, 0, err
+	}
+	for i := range hits {
+		hits[i].PrayerRequest = items[i]
+	}
+	return hits, total, nil
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+
+	"parish-viva/backend/internal/models"
+)
+
+func (s *Service) ArchiveStalePrayerRequests(ctx context.Context) (models.ArchivalResult, error) {
+	policy := s.opts.Archival
+	if policy.InactiveFor <= 0 {
+		return models.ArchivalResult{}, nil
+	}
+	return s.repo.ArchiveStalePrayerRequests(ctx, policy)
+}
+
+func (s *Service) KeepPrayerRequestActive(ctx context.Context, authorID, requestID string) (models.PrayerRequest, error) {
+	if err := s.repo.KeepPrayerRequestActive(ctx, authorID, requestID); err != nil {
+		return models.PrayerRequest{}, err
+	}
+	return s.repo.GetPrayerRequestByID(ctx, authorID, requestID)
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+)
+
+func (s *Service) BlockUser(ctx context.Context, userID, username string) (models.BlockedUser, error) {
+	username = normalizeUsername(username)
+	if username == "" {
+		return models.BlockedUser{}, repositories.ErrUserNotFound
+	}
+	return s.repo.BlockUser(ctx, userID, username)
+}
+
+func (s *Service) UnblockUser(ctx context.Context, userID, username string) error {
+	username = normalizeUsername(username)
+	if username == "" {
+		return repositories.ErrBlockNotFound
+	}
+	return s.repo.UnblockUser(ctx, userID, username)
+}
+
+func (s *Service) ListBlockedUsers(ctx context.Context, userID string) ([]models.BlockedUser, error) {
+	return s.repo.ListBlockedUsers(ctx, userID)
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+func (s *Service) CreateComment(ctx context.Context, in models.CreateCommentInput) (models.Comment, error) {
+	in.Body = strings.TrimSpace(in.Body)
+	if len(in.Body) < 1 || len([]rune(in.Body)) > 1000 {
+		return models.Comment{}, ErrInvalidCommentBody
+	}
+	in.ParentID = strings.TrimSpace(in.ParentID)
+	if in.ParentID != "" {
+		if _, err := uuid.Parse(in.ParentID); err != nil {
+			return models.Comment{}, repositories.ErrInvalidCommentParent
+		}
+	}
+	pr, err := s.repo.GetPrayerRequestByID(ctx, in.AuthorID, in.RequestID)
+	if err != nil {
+		return models.Comment{}, err
+	}
+	if pr.Status != models.StatusActive {
+		return models.Comment{}, repositories.ErrPrayerRequestNotActive
+	}
+	if !pr.CommentsEnabled {
+		return models.Comment{}, ErrCommentsDisabled
+	}
+	reviewGroupIDs, err := s.groupsNeedingReview(ctx, in.AuthorID, pr.GroupIDs)
+	if err != nil {
+		return models.Comment{}, err
+	}
+	in.ReviewGroupIDs = reviewGroupIDs
+	return s.repo.CreateComment(ctx, in)
+}
+
+// ListComments returns comments to anyone who can see the request itself.
+func (s *Service) ListComments(ctx context.Context, viewerID, requestID string, limit, offset int) ([]models.Comment, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := s.repo.GetPrayerRequestByID(ctx, viewerID, requestID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListComments(ctx, viewerID, requestID, limit, offset)
+}
+
+// ListCommentReplies pages through the replies to one comment.
+func (s *Service) ListCommentReplies(ctx context.Context, viewerID, requestID, commentID string, limit, offset int) ([]models.Comment, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := uuid.Parse(commentID); err != nil {
+		return nil, 0, repositories.ErrCommentNotFound
+	}
+	if _, err := s.repo.GetPrayerRequestByID(ctx, viewerID, requestID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListCommentReplies(ctx, viewerID, requestID, commentID, limit, offset)
+}
+
+func (s *Service) DeleteComment(ctx context.Context, userID, requestID, commentID string) error {
+	if _, err := uuid.Parse(commentID); err != nil {
+		return repositories.ErrCommentNotFound
+	}
+	return s.repo.DeleteComment(ctx, userID, requestID, commentID)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"parish-viva/backend/internal/mail"
+	"parish-viva/backend/internal/models"
+)
+
+const (
+	digestBatchSize = 50
+	// digestItemLimit caps each list; the rest are only counted.
+	digestItemLimit = 10
+)
+
+// SendEmailDigests skips empty digests and retries failed ones next pass.
+func (s *Service) SendEmailDigests(ctx context.Context) (models.EmailDigestResult, error) {
+	opts := s.opts.Email
+	if opts.Mailer == nil {
+		return models.EmailDigestResult{}, nil
+	}
+	recipients, err := s.repo.ClaimDueDigests(ctx, opts.DigestHour, digestBatchSize)
+	if err != nil {
+		return models.EmailDigestResult{}, err
+	}
+	result := models.EmailDigestResult{Claimed: len(recipients)}
+	now := time.Now()
+	for i, rcpt := range recipients {
+		since := now.Add(-digestPeriod(rcpt.Frequency))
+		if rcpt.PreviousSentAt != nil && rcpt.PreviousSentAt.After(since) {
+			since = *rcpt.PreviousSentAt
+		}
+		outcome, err := s.sendEmailDigest(ctx, rcpt, since)
+		if err != nil {
+			// Put back every digest not sent yet, even if ctx is done.
+			restoreCtx := context.WithoutCancel(ctx)
+			for _, pending := range recipients[i:] {
+				_ = s.repo.RestoreDigestSchedule(restoreCtx, pending.UserID, pending.PreviousSentAt)
+			}
+			result.Failed += len(recipients) - i
+			return result, err
+		}
+		switch outcome {
+		case digestSent:
+			result.Sent++
+		case digestEmpty:
+			result.Empty++
+		default:
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+type digestOutcome int
+
+const (
+	digestSent digestOutcome = iota
+	digestEmpty
+	digestRejected
+)
+
+func (s *Service) sendEmailDigest(ctx context.Context, rcpt models.DigestRecipient, since time.Time) (digestOutcome, error) {
+	opts := s.opts.Email
+	digest, err := s.repo.GetEmailDigest(ctx, rcpt.UserID, since, digestItemLimit)
+	if err != nil {
+		return 0, err
+	}
+	if digest.UnreadCount == 0 && digest.RequestCount == 0 {
+		return digestEmpty, nil
+	}
+	digest.Frequency = rcpt.Frequency
+
+	msg, err := mail.RenderDigest(digest, opts.AppBaseURL, unsubscribeURL(opts.APIBaseURL, rcpt.UnsubscribeToken))
+	if err != nil {
+		return 0, err
+	}
+	if err = opts.Mailer.Send(ctx, msg); err != nil {
+		if errors.Is(err, mail.ErrRejected) {
+			return digestRejected, nil
+		}
+		return 0, err
+	}
+	return digestSent, nil
+}
+
+func digestPeriod(frequency models.DigestFrequency) time.Duration {
+	if frequency == models.DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+func unsubscribeURL(apiBaseURL, token string) string {
+	return strings.TrimRight(apiBaseURL, "/") + "/api/v1/email/unsubscribe/" + token
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"parish-viva/backend/internal/mail"
+	"parish-viva/backend/internal/models"
+)
+
+const (
+	// emailOutboxBatchSize times smtpTimeout must stay under emailClaimLease.
+	emailOutboxBatchSize = 10
+	emailClaimLease      = 10 * time.Minute
+	outboxRetryBase      = time.Minute
+	outboxRetryMax       = time.Hour
+)
+
+type EmailOptions struct {
+	// Mailer delivers notification emails. Nil disables email delivery.
+	Mailer mail.Mailer
+	// AppBaseURL is the web app address that email links point to.
+	AppBaseURL string
+	// MaxAttempts is how many times an email is tried before it is dropped.
+	MaxAttempts int
+	// APIBaseURL is the public API address, used for unsubscribe links.
+	APIBaseURL string
+	// DigestHour is the local hour at which digests go out.
+	DigestHour int
+}
+
+// DeliverNotificationEmails sends one batch from the email outbox.
+func (s *Service) DeliverNotificationEmails(ctx context.Context) (models.EmailOutboxResult, error) {
+	opts := s.opts.Email
+	if opts.Mailer == nil {
+		return models.EmailOutboxResult{}, nil
+	}
+	emails, err := s.repo.ClaimNotificationEmails(ctx, emailOutboxBatchSize, emailClaimLease)
+	if err != nil {
+		return models.EmailOutboxResult{}, err
+	}
+	result := models.EmailOutboxResult{Claimed: len(emails)}
+	for _, e := range emails {
+		if !e.EmailEnabled || e.RecipientEmail == "" {
+			if err = s.repo.MarkNotificationEmailSkipped(ctx, e.NotificationID, "email notifications disabled"); err != nil {
+				return result, err
+			}
+			result.Skipped++
+			continue
+		}
+		if e.Digest != models.DigestOff {
+			if err = s.repo.MarkNotificationEmailSkipped(ctx, e.NotificationID, "sent in digest"); err != nil {
+				return result, err
+			}
+			result.Skipped++
+			continue
+		}
+		msg, err := mail.Render(e, opts.AppBaseURL)
+		if err != nil {
+			if err = s.repo.MarkNotificationEmailSkipped(ctx, e.NotificationID, err.Error()); err != nil {
+				return result, err
+			}
+			result.Skipped++
+			continue
+		}
+
+		sendErr := opts.Mailer.Send(ctx, msg)
+		if ctx.Err() != nil {
+			// The lease runs out and another pass picks the email up again.
+			return result, ctx.Err()
+		}
+		switch {
+		case sendErr == nil:
+			err = s.repo.MarkNotificationEmailSent(ctx, e.NotificationID)
+			result.Sent++
+		case errors.Is(sendErr, mail.ErrRejected) || e.Attempts >= opts.MaxAttempts:
+			err = s.repo.MarkNotificationEmailSkipped(ctx, e.NotificationID, sendErr.Error())
+			result.Skipped++
+		default:
+			err = s.repo.RetryNotificationEmail(ctx, e.NotificationID, sendErr.Error(), outboxRetryDelay(e.Attempts))
+			result.Retried++
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// outboxRetryDelay doubles after each attempt, up to outboxRetryMax.
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := outboxRetryBase
+	for i := 1; i < attempts && delay < outboxRetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxRetryMax)
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"parish-viva/backend/internal/models"
+)
+
+func normalizeFeedFilter(filter models.FeedFilter) (models.FeedFilter, error) {
+	for _, status := range filter.Statuses {
+		switch status {
+		case models.StatusActive, models.StatusClosed, models.StatusPendingReview:
+		default:
+			return models.FeedFilter{}, ErrInvalidFeedStatus
+		}
+	}
+	if filter.AnsweredOnly && len(filter.Statuses) > 0 {
+		return models.FeedFilter{}, ErrAnsweredWithStatus
+	}
+	for _, category := range filter.Categories {
+		if !isValidPrayerCategory(category) {
+			return models.FeedFilter{}, ErrInvalidCategory
+		}
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return models.FeedFilter{}, ErrInvalidDateRange
+	}
+	filter.AuthorUsername = normalizeUsername(filter.AuthorUsername)
+	return filter, nil
+}
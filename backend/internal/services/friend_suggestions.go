@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+)
+
+// suggestionPrayerWindow is how far back shared prayers count.
+const suggestionPrayerWindow = 90 * 24 * time.Hour
+
+// ListFriendSuggestions returns people the user may know, best match first.
+func (s *Service) ListFriendSuggestions(ctx context.Context, userID string, limit int) ([]models.FriendSuggestion, error) {
+	return s.repo.ListFriendSuggestions(ctx, userID, suggestionPrayerWindow, limit)
+}
+
+func (s *Service) DismissFriendSuggestion(ctx context.Context, userID, dismissedUserID string) error {
+	if _, err := uuid.Parse(dismissedUserID); err != nil {
+		return repositories.ErrUserNotFound
+	}
+	return s.repo.DismissFriendSuggestion(ctx, userID, dismissedUserID)
+}
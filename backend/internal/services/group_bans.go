@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+)
+
+// requireGroupModerator fails below MODERATOR.
+func (s *Service) requireGroupModerator(ctx context.Context, actorUserID, groupID string) (models.GroupRole, error) {
+	role, isMember, err := s.repo.GetGroupRoleOf(ctx, actorUserID, groupID)
+	if err != nil {
+		return "", err
+	}
+	if !isMember || models.RoleRank(role) < models.RoleRank(models.RoleModerator) {
+		return "", ErrPermissionDenied
+	}
+	return role, nil
+}
+
+// BanGroupMember requires the actor to outrank a target who is still a member.
+func (s *Service) BanGroupMember(ctx context.Context, in models.CreateGroupBanInput) (models.GroupBan, error) {
+	if in.ActorUserID == in.UserID {
+		return models.GroupBan{}, ErrCannotTargetSelf
+	}
+	reason, _, err := validateDecisionText(models.ActionBan, in.Reason, "")
+	if err != nil {
+		return models.GroupBan{}, err
+	}
+	in.Reason = reason
+	if in.ExpiresAt != nil && !in.ExpiresAt.After(time.Now()) {
+		return models.GroupBan{}, ErrInvalidBanExpiry
+	}
+	actorRole, err := s.requireGroupModerator(ctx, in.ActorUserID, in.GroupID)
+	if err != nil {
+		return models.GroupBan{}, err
+	}
+	targetRole, isTargetMember, err := s.repo.GetGroupRoleOf(ctx, in.UserID, in.GroupID)
+	if err != nil {
+		return models.GroupBan{}, err
+	}
+	if isTargetMember && models.RoleRank(actorRole) <= models.RoleRank(targetRole) {
+		return models.GroupBan{}, ErrPermissionDenied
+	}
+	return s.repo.BanGroupMember(ctx, in)
+}
+
+func (s *Service) UnbanGroupMember(ctx context.Context, actorUserID, groupID, userID string) error {
+	if _, err := s.requireGroupModerator(ctx, actorUserID, groupID); err != nil {
+		return err
+	}
+	return s.repo.UnbanGroupMember(ctx, actorUserID, groupID, userID)
+}
+
+func (s *Service) ListGroupBans(ctx context.Context, actorUserID, groupID string, limit, offset int) ([]models.GroupBan, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := s.requireGroupModerator(ctx, actorUserID, groupID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListGroupBans(ctx, groupID, limit, offset)
+}
+
+func (s *Service) ensureNotGroupBanned(ctx context.Context, groupID, userID string) error {
+	banned, err := s.repo.IsGroupBanned(ctx, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return repositories.ErrGroupBanned
+	}
+	return nil
+}
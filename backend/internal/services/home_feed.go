@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"parish-viva/backend/internal/feed"
+	"parish-viva/backend/internal/models"
+)
+
+// relevantFeedPool is how many of the newest requests get ranked.
+const relevantFeedPool = 300
+
+// ListRelevantHomePrayerRequests pages by offset since the ranking shifts.
+func (s *Service) ListRelevantHomePrayerRequests(ctx context.Context, userID string, filter models.FeedFilter, limit, offset int) ([]models.PrayerRequest, int64, error) {
+	filter, err := normalizeFeedFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	candidates, err := s.repo.ListHomeFeedCandidates(ctx, userID, filter, relevantFeedPool)
+	if err != nil {
+		return nil, 0, err
+	}
+	ranked := feed.Rank(candidates, time.Now(), feed.DefaultWeights)
+	total := int64(len(ranked))
+	if offset >= len(ranked) {
+		return []models.PrayerRequest{}, total, nil
+	}
+	ranked = ranked[offset:min(offset+limit, len(ranked))]
+
+	ids := make([]string, len(ranked))
+	for i, c := range ranked {
+		ids[i] = c.RequestID
+	}
+	items, err := s.repo.ListPrayerRequestsByIDs(ctx, userID, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
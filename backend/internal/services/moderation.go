@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"parish-viva/backend/internal/models"
+)
+
+// moderationScope returns true for platform moderators, else their groups.
+func (s *Service) moderationScope(ctx context.Context, userID string) (bool, []string, error) {
+	role, err := s.repo.GetPlatformRole(ctx, userID)
+	if err != nil {
+		return false, nil, err
+	}
+	if models.IsPlatformModerator(role) {
+		return true, nil, nil
+	}
+	groupIDs, err := s.repo.ListModeratedGroupIDs(ctx, userID)
+	if err != nil {
+		return false, nil, err
+	}
+	return false, groupIDs, nil
+}
+
+func (s *Service) isPlatformModerator(ctx context.Context, userID string) (bool, error) {
+	role, err := s.repo.GetPlatformRole(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return models.IsPlatformModerator(role), nil
+}
+
+// canModerate treats a nil groupID as public, platform-only content.
+func (s *Service) canModerate(ctx context.Context, userID string, groupID *string) (bool, error) {
+	role, err := s.repo.GetPlatformRole(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if models.IsPlatformModerator(role) {
+		return true, nil
+	}
+	if groupID == nil {
+		return false, nil
+	}
+	groupRole, isMember, err := s.repo.GetGroupRoleOf(ctx, userID, *groupID)
+	if err != nil {
+		return false, err
+	}
+	return isMember && models.RoleRank(groupRole) >= models.RoleRank(models.RoleModerator), nil
+}
+
+// reviewScopes lists the queues a request must clear before going live.
+func (s *Service) reviewScopes(ctx context.Context, authorID string, visibility models.Visibility, groupIDs []string) ([]string, bool, error) {
+	reviewGroupIDs, err := s.groupsNeedingReview(ctx, authorID, groupIDs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	reviewPublic := false
+	if visibility == models.VisibilityPublic && s.opts.PublicModeration {
+		role, err := s.repo.GetPlatformRole(ctx, authorID)
+		if err != nil {
+			return nil, false, err
+		}
+		reviewPublic = !models.IsPlatformModerator(role)
+	}
+	return reviewGroupIDs, reviewPublic, nil
+}
+
+// groupsNeedingReview skips groups the author moderates.
+func (s *Service) groupsNeedingReview(ctx context.Context, authorID string, groupIDs []string) ([]string, error) {
+	moderated, err := s.repo.FilterGroupsRequiringModeration(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	reviewGroupIDs := make([]string, 0, len(moderated))
+	for _, groupID := range moderated {
+		id := groupID
+		ok, err := s.canModerate(ctx, authorID, &id)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			reviewGroupIDs = append(reviewGroupIDs, groupID)
+		}
+	}
+	return reviewGroupIDs, nil
+}
+
+func (s *Service) ListModerationQueue(ctx context.Context, viewerID string, filter models.ModerationQueueFilter) ([]models.ModerationQueueItem, int64, error) {
+	for _, status := range filter.Statuses {
+		if !isValidModerationQueueStatus(status) {
+			return nil, 0, ErrInvalidModerationStatus
+		}
+	}
+	if filter.Reason != "" && !isValidModerationReason(filter.Reason) {
+		return nil, 0, ErrInvalidModerationReason
+	}
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []models.ModerationQueueStatus{models.QueueStatusPending, models.QueueStatusInReview}
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	platform, groupIDs, err := s.moderationScope(ctx, viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !platform && len(groupIDs) == 0 {
+		return nil, 0, ErrPermissionDenied
+	}
+	if filter.GroupID != "" && !platform && !containsString(groupIDs, filter.GroupID) {
+		return nil, 0, ErrPermissionDenied
+	}
+	filter.PlatformScope = platform
+	filter.GroupIDs = groupIDs
+	return s.repo.ListModerationQueue(ctx, filter)
+}
+
+func (s *Service) ClaimModerationItem(ctx context.Context, moderatorID, itemID string) (models.ModerationQueueItem, error) {
+	item, err := s.repo.GetModerationQueueItem(ctx, itemID)
+	if err != nil {
+		return models.ModerationQueueItem{}, err
+	}
+	ok, err := s.canModerate(ctx, moderatorID, item.GroupID)
+	if err != nil {
+		return models.ModerationQueueItem{}, err
+	}
+	if !ok {
+		return models.ModerationQueueItem{}, ErrPermissionDenied
+	}
+	return s.repo.ClaimModerationQueueItem(ctx, itemID, moderatorID)
+}
+
+func (s *Service) ApproveModerationItem(ctx context.Context, moderatorID, itemID, note string) (models.ModerationAction, error) {
+	return s.decideOnQueueItem(ctx, moderatorID, itemID, models.ModerationDecision{
+		Action:         models.ActionApprove,
+		FromStatus:     models.StatusPendingReview,
+		ToStatus:       models.StatusActive,
+		WhenQueueClear: true,
+		Note:           note,
+	})
+}
+
+func (s *Service) RejectModerationItem(ctx context.Context, moderatorID, itemID, reason, note string) (models.ModerationAction, error) {
+	return s.decideOnQueueItem(ctx, moderatorID, itemID, models.ModerationDecision{
+		Action:     models.ActionReject,
+		ToStatus:   models.StatusRemoved,
+		ResolveAll: true,
+		Reason:     reason,
+		Note:       note,
+	})
+}
+
+// RequestChangesOnModerationItem hides the request until it is edited.
+func (s *Service) RequestChangesOnModerationItem(ctx context.Context, moderatorID, itemID, reason, note string) (models.ModerationAction, error) {
+	return s.decideOnQueueItem(ctx, moderatorID, itemID, models.ModerationDecision{
+		Action:     models.ActionRequestChanges,
+		ToStatus:   models.StatusPendingReview,
+		ResolveAll: true,
+		Reason:     reason,
+		Note:       note,
+	})
+}
+
+func (s *Service) decideOnQueueItem(ctx context.Context, moderatorID, itemID string, d models.ModerationDecision) (models.ModerationAction, error) {
+	reason, note, err := validateDecisionText(d.Action, d.Reason, d.Note)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	item, err := s.repo.GetModerationQueueItem(ctx, itemID)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	if item.CommentID != nil {
+		return s.decideOnComment(ctx, moderatorID, item, d.Action, reason, note)
+	}
+	if item.PrayerRequestID == nil {
+		return models.ModerationAction{}, ErrModerationTargetMismatch
+	}
+	ok, err := s.canModerate(ctx, moderatorID, item.GroupID)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	if !ok {
+		return models.ModerationAction{}, ErrPermissionDenied
+	}
+	if d.Action == models.ActionReject && item.GroupID != nil {
+		platform, err := s.isPlatformModerator(ctx, moderatorID)
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+		d.GroupOnly = !platform
+	}
+	d.ActorUserID = moderatorID
+	d.RequestID = *item.PrayerRequestID
+	d.QueueItemID = item.ID
+	d.GroupID = item.GroupID
+	d.Reason = reason
+	d.Note = note
+	return s.repo.ApplyModerationDecision(ctx, d)
+}
+
+// decideOnComment only approves or rejects.
+func (s *Service) decideOnComment(ctx context.Context, moderatorID string, item models.ModerationQueueItem, action models.ModerationActionType, reason, note string) (models.ModerationAction, error) {
+	if action != models.ActionApprove && action != models.ActionReject {
+		return models.ModerationAction{}, ErrModerationTargetMismatch
+	}
+	ok, err := s.canModerate(ctx, moderatorID, item.GroupID)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	if !ok {
+		return models.ModerationAction{}, ErrPermissionDenied
+	}
+	return s.repo.ApplyCommentDecision(ctx, models.CommentDecision{
+		ActorUserID: moderatorID,
+		Action:      action,
+		QueueItemID: item.ID,
+		CommentID:   *item.CommentID,
+		GroupID:     item.GroupID,
+		Reason:      reason,
+		Note:        note,
+	})
+}
+
+// RemovePrayerRequest limits group moderators to their own group.
+func (s *Service) RemovePrayerRequest(ctx context.Context, moderatorID, requestID, reason, note string) (models.ModerationAction, error) {
+	reason, note, err := validateDecisionText(models.ActionRemove, reason, note)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	groupID, err := s.moderatedGroupForRequest(ctx, moderatorID, requestID)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	return s.repo.ApplyModerationDecision(ctx, models.ModerationDecision{
+		ActorUserID: moderatorID,
+		Action:      models.ActionRemove,
+		RequestID:   requestID,
+		GroupID:     groupID,
+		ToStatus:    models.StatusRemoved,
+		ResolveAll:  true,
+		GroupOnly:   groupID != nil,
+		Reason:      reason,
+		Note:        note,
+	})
+}
+
+func (s *Service) ListModerationActions(ctx context.Context, moderatorID, requestID string) ([]models.ModerationAction, error) {
+	if _, err := s.moderatedGroupForRequest(ctx, moderatorID, requestID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListModerationActions(ctx, requestID)
+}
+
+// RevertModerationAction is for platform admins, or group admins in their group.
+func (s *Service) RevertModerationAction(ctx context.Context, adminID, actionID string) (models.ModerationAction, error) {
+	action, err := s.repo.GetModerationAction(ctx, actionID)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	role, err := s.repo.GetPlatformRole(ctx, adminID)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	if role != models.PlatformRoleAdmin {
+		if action.TargetGroupID == nil {
+			return models.ModerationAction{}, ErrPermissionDenied
+		}
+		groupRole, isMember, err := s.repo.GetGroupRoleOf(ctx, adminID, *action.TargetGroupID)
+		if err != nil {
+			return models.ModerationAction{}, err
+		}
+		if !isMember || groupRole != models.RoleAdmin {
+			return models.ModerationAction{}, ErrPermissionDenied
+		}
+	}
+	return s.repo.RevertModerationAction(ctx, adminID, actionID)
+}
+
+// moderatedGroupForRequest returns nil for platform moderators.
+func (s *Service) moderatedGroupForRequest(ctx context.Context, moderatorID, requestID string) (*string, error) {
+	pr, err := s.repo.GetPrayerRequestForModeration(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.repo.GetPlatformRole(ctx, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+	if models.IsPlatformModerator(role) {
+		return nil, nil
+	}
+	for _, groupID := range pr.GroupIDs {
+		id := groupID
+		ok, err := s.canModerate(ctx, moderatorID, &id)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return &id, nil
+		}
+	}
+	return nil, ErrPermissionDenied
+}
+
+func validateDecisionText(action models.ModerationActionType, reason, note string) (string, string, error) {
+	reason = strings.TrimSpace(reason)
+	note = strings.TrimSpace(note)
+	if reason == "" && action != models.ActionApprove {
+		return "", "", ErrDecisionReasonRequired
+	}
+	if len([]rune(reason)) > 200 {
+		return "", "", ErrInvalidDecisionReason
+	}
+	if len([]rune(note)) > 1000 {
+		return "", "", ErrInvalidDecisionNote
+	}
+	return reason, note, nil
+}
+
+func isValidModerationQueueStatus(status models.ModerationQueueStatus) bool {
+	switch status {
+	case models.QueueStatusPending, models.QueueStatusInReview, models.QueueStatusResolved:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidModerationReason(reason models.ModerationReason) bool {
+	switch reason {
+	case models.ReasonGroupModeration, models.ReasonPublicModeration, models.ReasonEdited, models.ReasonReverted, models.ReasonReported:
+		return true
+	default:
+		return false
+	}
+}
+
+func containsString(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
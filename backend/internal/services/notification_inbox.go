@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"parish-viva/backend/internal/models"
+)
+
+// notificationPurgeBatch keeps each retention delete small.
+const notificationPurgeBatch = 1000
+
+// UnreadNotificationCounts returns the unread total and the split by type.
+func (s *Service) UnreadNotificationCounts(ctx context.Context, userID string) (models.UnreadNotificationCounts, error) {
+	byType, err := s.repo.CountUnreadNotificationsByType(ctx, userID)
+	if err != nil {
+		return models.UnreadNotificationCounts{}, err
+	}
+	counts := models.UnreadNotificationCounts{ByType: make(map[models.NotificationType]int64, len(models.NotificationTypes))}
+	for _, t := range models.NotificationTypes {
+		counts.ByType[t] = 0
+	}
+	for t, n := range byType {
+		counts.ByType[t] = n
+		counts.Count += n
+	}
+	return counts, nil
+}
+
+func (s *Service) DeleteNotification(ctx context.Context, userID, id string) error {
+	return s.repo.DeleteNotification(ctx, userID, id)
+}
+
+func (s *Service) DeleteReadNotifications(ctx context.Context, userID string) (int64, error) {
+	return s.repo.DeleteReadNotifications(ctx, userID)
+}
+
+func (s *Service) PurgeOldNotifications(ctx context.Context) (int64, error) {
+	retention := s.opts.NotificationRetention
+	if retention <= 0 {
+		return 0, nil
+	}
+	var total int64
+	for {
+		n, err := s.repo.PurgeNotifications(ctx, retention, notificationPurgeBatch)
+		total += n
+		if err != nil || n < notificationPurgeBatch || ctx.Err() != nil {
+			return total, err
+		}
+	}
+}
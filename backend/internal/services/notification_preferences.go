@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+func (s *Service) GetNotificationPreferences(ctx context.Context, userID string) (models.NotificationPreferences, error) {
+	return s.repo.GetNotificationPreferences(ctx, userID)
+}
+
+func (s *Service) UpdateNotificationPreferences(ctx context.Context, userID string, in models.UpdateNotificationPreferencesInput) (models.NotificationPreferences, error) {
+	if in.EmailDigest != nil {
+		switch *in.EmailDigest {
+		case models.DigestOff, models.DigestDaily, models.DigestWeekly:
+		default:
+			return models.NotificationPreferences{}, ErrInvalidDigestFrequency
+		}
+	}
+	if in.Timezone != nil {
+		tz := strings.TrimSpace(*in.Timezone)
+		if _, err := time.LoadLocation(tz); err != nil || tz == "" || tz == "Local" {
+			return models.NotificationPreferences{}, ErrInvalidTimezone
+		}
+		in.Timezone = &tz
+	}
+	for t := range in.Types {
+		if !slices.Contains(models.NotificationTypes, t) {
+			return models.NotificationPreferences{}, ErrInvalidNotificationType
+		}
+	}
+	if in.QuietHours != nil {
+		if !validQuietHour(in.QuietHours.Start) || !validQuietHour(in.QuietHours.End) || in.QuietHours.Start == in.QuietHours.End {
+			return models.NotificationPreferences{}, ErrInvalidQuietHours
+		}
+	}
+	if in.MutedGroupIDs != nil {
+		groupIDs := make([]string, 0, len(*in.MutedGroupIDs))
+		for _, id := range *in.MutedGroupIDs {
+			parsed, err := uuid.Parse(id)
+			if err != nil {
+				return models.NotificationPreferences{}, ErrInvalidMutedGroupID
+			}
+			if !slices.Contains(groupIDs, parsed.String()) {
+				groupIDs = append(groupIDs, parsed.String())
+			}
+		}
+		in.MutedGroupIDs = &groupIDs
+	}
+	return s.repo.UpdateNotificationPreferences(ctx, userID, in)
+}
+
+// validQuietHour accepts a 24-hour "HH:MM" time.
+func validQuietHour(v string) bool {
+	_, err := time.Parse("15:04", v)
+	return err == nil && len(v) == len("15:04")
+}
+
+// UnsubscribeFromEmails handles the one-click link in digest emails.
+func (s *Service) UnsubscribeFromEmails(ctx context.Context, token string) error {
+	if _, err := uuid.Parse(token); err != nil {
+		return repositories.ErrUnsubscribeTokenNotFound
+	}
+	return s.repo.UnsubscribeFromEmails(ctx, token)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"parish-viva/backend/internal/models"
+)
+
+// notificationStreamBuffer is how far a client may fall behind.
+const notificationStreamBuffer = 32
+
+// notificationReplayLimit caps a reconnecting stream's replay.
+const notificationReplayLimit = 100
+
+// NotificationStream's Signals is closed when the stream is dropped.
+type NotificationStream struct {
+	userID  string
+	signals chan models.NotificationSignal
+	hub     *notificationHub
+}
+
+func (st *NotificationStream) Signals() <-chan models.NotificationSignal {
+	return st.signals
+}
+
+// Close detaches the stream; it is safe to call more than once.
+func (st *NotificationStream) Close() {
+	st.hub.remove(st)
+}
+
+// notificationHub fans signals out to this replica's streams.
+type notificationHub struct {
+	mu      sync.Mutex
+	perUser int
+	streams map[string]map[*NotificationStream]struct{}
+}
+
+func newNotificationHub(perUser int) *notificationHub {
+	return &notificationHub{perUser: perUser, streams: map[string]map[*NotificationStream]struct{}{}}
+}
+
+func (h *notificationHub) open(userID string) (*NotificationStream, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.perUser > 0 && len(h.streams[userID]) >= h.perUser {
+		return nil, ErrTooManyNotificationStreams
+	}
+	st := &NotificationStream{userID: userID, signals: make(chan models.NotificationSignal, notificationStreamBuffer), hub: h}
+	if h.streams[userID] == nil {
+		h.streams[userID] = map[*NotificationStream]struct{}{}
+	}
+	h.streams[userID][st] = struct{}{}
+	return st, nil
+}
+
+// publish never blocks: a stream whose buffer is full is dropped.
+func (h *notificationHub) publish(signal models.NotificationSignal) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for st := range h.streams[signal.UserID] {
+		select {
+		case st.signals <- signal:
+		default:
+			h.removeLocked(st)
+		}
+	}
+}
+
+func (h *notificationHub) remove(st *NotificationStream) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(st)
+}
+
+func (h *notificationHub) removeLocked(st *NotificationStream) {
+	userStreams := h.streams[st.userID]
+	if _, ok := userStreams[st]; !ok {
+		return
+	}
+	delete(userStreams, st)
+	if len(userStreams) == 0 {
+		delete(h.streams, st.userID)
+	}
+	close(st.signals)
+}
+
+// OpenNotificationStream enforces NotificationStreamsPerUser.
+func (s *Service) OpenNotificationStream(userID string) (*NotificationStream, error) {
+	return s.streams.open(userID)
+}
+
+func (s *Service) ListenForNotifications(ctx context.Context) error {
+	return s.repo.ListenNotifications(ctx, s.streams.publish)
+}
+
+func (s *Service) GetNotification(ctx context.Context, userID, id string) (models.NotificationView, error) {
+	return s.repo.GetNotification(ctx, userID, id)
+}
+
+func (s *Service) ListNotificationsAfter(ctx context.Context, userID, lastEventID string) ([]models.NotificationView, error) {
+	return s.repo.ListNotificationsAfter(ctx, userID, lastEventID, notificationReplayLimit)
+}
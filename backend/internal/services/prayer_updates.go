@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"parish-viva/backend/internal/models"
+)
+
+func (s *Service) CreatePrayerUpdate(ctx context.Context, authorID, requestID, body string) (models.PrayerUpdate, error) {
+	body = strings.TrimSpace(body)
+	if len(body) < 1 || len([]rune(body)) > 2000 {
+		return models.PrayerUpdate{}, ErrInvalidUpdateBody
+	}
+	return s.repo.CreatePrayerUpdate(ctx, authorID, requestID, body)
+}
+
+// ListPrayerUpdates is open to anyone who can see the request.
+func (s *Service) ListPrayerUpdates(ctx context.Context, viewerID, requestID string, limit, offset int) ([]models.PrayerUpdate, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := s.repo.GetPrayerRequestByID(ctx, viewerID, requestID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListPrayerUpdates(ctx, requestID, limit, offset)
+}
+
+// ResolvePrayerRequest only keeps a testimony for answered prayers.
+func (s *Service) ResolvePrayerRequest(ctx context.Context, in models.ClosePrayerRequestInput) error {
+	in.Testimony = strings.TrimSpace(in.Testimony)
+	if in.Resolution != models.ResolutionAnswered {
+		in.Testimony = ""
+	}
+	if len([]rune(in.Testimony)) > 2000 {
+		return ErrInvalidTestimony
+	}
+	return s.repo.ClosePrayerRequest(ctx, in)
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+
+	"parish-viva/backend/internal/models"
+)
+
+func (s *Service) GetPrivacySettings(ctx context.Context, userID string) (models.PrivacySettings, error) {
+	return s.repo.GetPrivacySettings(ctx, userID)
+}
+
+func (s *Service) UpdatePrivacySettings(ctx context.Context, userID string, in models.UpdatePrivacySettingsInput) (models.PrivacySettings, error) {
+	if in.ProfileVisibility != nil && !isValidProfileAudience(*in.ProfileVisibility) {
+		return models.PrivacySettings{}, ErrInvalidProfileVisibility
+	}
+	if in.StatsVisibility != nil && !isValidProfileAudience(*in.StatsVisibility) {
+		return models.PrivacySettings{}, ErrInvalidStatsVisibility
+	}
+	if in.FriendRequests != nil {
+		switch *in.FriendRequests {
+		case models.FriendRequestsEveryone, models.FriendRequestsFriendsOfFriends, models.FriendRequestsNobody:
+		default:
+			return models.PrivacySettings{}, ErrInvalidFriendRequestPolicy
+		}
+	}
+	return s.repo.UpdatePrivacySettings(ctx, userID, in)
+}
+
+func isValidProfileAudience(a models.ProfileAudience) bool {
+	switch a {
+	case models.ProfileAudienceEveryone, models.ProfileAudienceFriends, models.ProfileAudienceNobody:
+		return true
+	default:
+		return false
+	}
+}
+
+func audienceIncludes(a models.ProfileAudience, isFriend bool) bool {
+	switch a {
+	case models.ProfileAudienceEveryone:
+		return true
+	case models.ProfileAudienceFriends:
+		return isFriend
+	default:
+		return false
+	}
+}
+
+func acceptsFriendRequest(policy models.FriendRequestPolicy, hasMutualFriend bool) bool {
+	switch policy {
+	case models.FriendRequestsEveryone:
+		return true
+	case models.FriendRequestsFriendsOfFriends:
+		return hasMutualFriend
+	default:
+		return false
+	}
+}
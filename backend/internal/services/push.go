@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"parish-viva/backend/internal/mail"
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/push"
+)
+
+const (
+	pushOutboxBatchSize = 10
+	// pushClaimLease is how long a batch is claimed; a pass stops a minute early.
+	pushClaimLease = 5 * time.Minute
+	// pushTitle heads every push message; the body says what happened.
+	pushTitle = "Creo"
+)
+
+type PushOptions struct {
+	// Sender delivers Web Push messages. Nil disables push.
+	Sender push.Sender
+	// PublicKey is the VAPID public key browsers subscribe with.
+	PublicKey string
+	// AppBaseURL is the web app address that push messages open.
+	AppBaseURL string
+	// MaxAttempts is how many times a push is tried before it is dropped.
+	MaxAttempts int
+}
+
+// pushMessage is the JSON the service worker receives.
+type pushMessage struct {
+	NotificationID string                  `json:"notificationId"`
+	Type           models.NotificationType `json:"type"`
+	Title          string                  `json:"title"`
+	Body           string                  `json:"body"`
+	URL            string                  `json:"url"`
+}
+
+// PushPublicKey returns the VAPID public key for PushManager.subscribe.
+func (s *Service) PushPublicKey() (string, error) {
+	if s.opts.Push.Sender == nil {
+		return "", ErrPushDisabled
+	}
+	return s.opts.Push.PublicKey, nil
+}
+
+func (s *Service) SavePushSubscription(ctx context.Context, userID string, sub models.PushSubscription) (models.PushSubscription, error) {
+	if s.opts.Push.Sender == nil {
+		return models.PushSubscription{}, ErrPushDisabled
+	}
+	sub.Endpoint = strings.TrimSpace(sub.Endpoint)
+	sub.P256dh = strings.TrimSpace(sub.P256dh)
+	sub.Auth = strings.TrimSpace(sub.Auth)
+	if err := push.ValidateSubscription(push.Subscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth}); err != nil {
+		return models.PushSubscription{}, ErrInvalidPushSubscription
+	}
+	return s.repo.SavePushSubscription(ctx, userID, sub)
+}
+
+func (s *Service) DeletePushSubscription(ctx context.Context, userID, endpoint string) error {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return ErrInvalidPushSubscription
+	}
+	return s.repo.DeletePushSubscription(ctx, userID, endpoint)
+}
+
+// DeliverNotificationPushes sends one batch from the push outbox.
+func (s *Service) DeliverNotificationPushes(ctx context.Context) (models.PushOutboxResult, error) {
+	opts := s.opts.Push
+	if opts.Sender == nil {
+		return models.PushOutboxResult{}, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, pushClaimLease-time.Minute)
+	defer cancel()
+	pushes, err := s.repo.ClaimNotificationPushes(ctx, pushOutboxBatchSize, pushClaimLease)
+	if err != nil {
+		return models.PushOutboxResult{}, err
+	}
+	result := models.PushOutboxResult{Claimed: len(pushes)}
+	for _, p := range pushes {
+		payload, skipReason := renderPush(p, opts.AppBaseURL)
+		if skipReason == "" && len(p.Subscriptions) == 0 {
+			skipReason = "no push subscriptions"
+		}
+		if skipReason != "" {
+			if err = s.repo.MarkNotificationPushSkipped(ctx, p.NotificationID, skipReason); err != nil {
+				return result, err
+			}
+			result.Skipped++
+			continue
+		}
+
+		var (
+			delivered bool
+			temporary bool
+			lastErr   error
+		)
+		for _, sub := range p.Subscriptions {
+			sendErr := opts.Sender.Send(ctx, push.Subscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth}, payload)
+			if ctx.Err() != nil {
+				// The lease runs out and another pass picks the push up again.
+				return result, ctx.Err()
+			}
+			switch {
+			case sendErr == nil:
+				delivered = true
+			case errors.Is(sendErr, push.ErrGone) || errors.Is(sendErr, push.ErrInvalidSubscription):
+				if err = s.repo.DeleteGonePushSubscription(ctx, sub.ID); err != nil {
+					return result, err
+				}
+				result.Gone++
+				lastErr = sendErr
+			case errors.Is(sendErr, push.ErrRejected):
+				lastErr = sendErr
+			default:
+				temporary = true
+				lastErr = sendErr
+			}
+		}
+
+		switch {
+		case delivered:
+			err = s.repo.MarkNotificationPushSent(ctx, p.NotificationID)
+			result.Sent++
+		case temporary && p.Attempts < opts.MaxAttempts:
+			err = s.repo.RetryNotificationPush(ctx, p.NotificationID, lastErr.Error(), outboxRetryDelay(p.Attempts))
+			result.Retried++
+		default:
+			err = s.repo.MarkNotificationPushSkipped(ctx, p.NotificationID, lastErr.Error())
+			result.Skipped++
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// renderPush returns why a notification cannot be pushed.
+func renderPush(p models.NotificationPush, baseURL string) ([]byte, string) {
+	body, ok, err := mail.Summary(p.NotificationEmail)
+	if err != nil {
+		return nil, err.Error()
+	}
+	if !ok {
+		return nil, "no template for type"
+	}
+	payload, err := json.Marshal(pushMessage{
+		NotificationID: p.NotificationID,
+		Type:           p.Type,
+		Title:          pushTitle,
+		Body:           body,
+		URL:            mail.Link(p.NotificationEmail, baseURL),
+	})
+	if err != nil {
+		return nil, err.Error()
+	}
+	return payload, ""
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/push"
+	"parish-viva/backend/internal/repositories"
+)
+
+// pushRepo records outbox calls; anything else hits the nil Repository.
+type pushRepo struct {
+	repositories.Repository
+	pushes  []models.NotificationPush
+	deleted []string
+	sent    []string
+	skipped []string
+	retried []string
+}
+
+func (r *pushRepo) ClaimNotificationPushes(context.Context, int, time.Duration) ([]models.NotificationPush, error) {
+	return r.pushes, nil
+}
+
+func (r *pushRepo) DeleteGonePushSubscription(_ context.Context, subscriptionID string) error {
+	r.deleted = append(r.deleted, subscriptionID)
+	return nil
+}
+
+func (r *pushRepo) MarkNotificationPushSent(_ context.Context, notificationID string) error {
+	r.sent = append(r.sent, notificationID)
+	return nil
+}
+
+func (r *pushRepo) MarkNotificationPushSkipped(_ context.Context, notificationID, _ string) error {
+	r.skipped = append(r.skipped, notificationID)
+	return nil
+}
+
+func (r *pushRepo) RetryNotificationPush(_ context.Context, notificationID, _ string, _ time.Duration) error {
+	r.retried = append(r.retried, notificationID)
+	return nil
+}
+
+func randomKey(t *testing.T) *ecdh.PrivateKey {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestDeliverNotificationPushesDeletesGoneSubscriptions(t *testing.T) {
+	// Each subscription's path is the status its push service answers with.
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/") {
+		case "404":
+			w.WriteHeader(http.StatusNotFound)
+		case "410":
+			w.WriteHeader(http.StatusGone)
+		case "503":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	vapid := randomKey(t)
+	sender, err := push.NewClient(push.Config{
+		PublicKey:  base64.RawURLEncoding.EncodeToString(vapid.PublicKey().Bytes()),
+		PrivateKey: base64.RawURLEncoding.EncodeToString(vapid.Bytes()),
+		Subject:    "mailto:push@example.com",
+		HTTPClient: server.Client(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscription := func(id, status string) models.PushSubscription {
+		return models.PushSubscription{
+			ID:       id,
+			Endpoint: server.URL + "/" + status,
+			P256dh:   base64.RawURLEncoding.EncodeToString(randomKey(t).PublicKey().Bytes()),
+			Auth:     base64.RawURLEncoding.EncodeToString(make([]byte, 16)),
+		}
+	}
+	notification := func(id string, subs ...models.PushSubscription) models.NotificationPush {
+		return models.NotificationPush{
+			NotificationEmail: models.NotificationEmail{
+				NotificationID: id,
+				Type:           models.NotificationTypeFriendRequestReceived,
+				ActorName:      "Ana",
+				Locale:         models.LocaleEnglish,
+			},
+			Subscriptions: subs,
+		}
+	}
+
+	repo := &pushRepo{pushes: []models.NotificationPush{
+		notification("n-delivered", subscription("s-gone", "410"), subscription("s-ok", "201")),
+		notification("n-gone", subscription("s-missing", "404"), subscription("s-gone-too", "410")),
+		notification("n-later", subscription("s-down", "503")),
+	}}
+	svc := NewService(repo, Options{Push: PushOptions{Sender: sender, AppBaseURL: "https://creo.example.com", MaxAttempts: 3}})
+
+	result, err := svc.DeliverNotificationPushes(context.Background())
+	if err != nil {
+		t.Fatalf("DeliverNotificationPushes: %v", err)
+	}
+
+	if got, want := strings.Join(repo.deleted, ","), "s-gone,s-missing,s-gone-too"; got != want {
+		t.Errorf("deleted subscriptions = %s, want %s", got, want)
+	}
+	if got := strings.Join(repo.sent, ","); got != "n-delivered" {
+		t.Errorf("sent = %s, want n-delivered", got)
+	}
+	if got := strings.Join(repo.skipped, ","); got != "n-gone" {
+		t.Errorf("skipped = %s, want n-gone", got)
+	}
+	if got := strings.Join(repo.retried, ","); got != "n-later" {
+		t.Errorf("retried = %s, want n-later", got)
+	}
+	want := models.PushOutboxResult{Claimed: 3, Sent: 1, Skipped: 1, Retried: 1, Gone: 3}
+	if result != want {
+		t.Errorf("result = %+v, want %+v", result, want)
+	}
+}
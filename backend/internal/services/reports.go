@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"parish-viva/backend/internal/models"
+	"parish-viva/backend/internal/repositories"
+)
+
+func (s *Service) ReportPrayerRequest(ctx context.Context, reporterID, requestID string, reason models.ReportReason, details string) (models.Report, bool, error) {
+	details, err := validateReport(reason, details)
+	if err != nil {
+		return models.Report{}, false, err
+	}
+	pr, err := s.repo.GetPrayerRequestByID(ctx, reporterID, requestID)
+	if err != nil {
+		return models.Report{}, false, err
+	}
+	if pr.AuthorID == reporterID {
+		return models.Report{}, false, ErrCannotTargetSelf
+	}
+	return s.repo.CreateReport(ctx, models.CreateReportInput{
+		ReporterID:        reporterID,
+		TargetRequestID:   pr.ID,
+		Reason:            reason,
+		Details:           details,
+		AutoHideThreshold: s.opts.ReportAutoHideThreshold,
+	})
+}
+
+func (s *Service) ReportUser(ctx context.Context, reporterID, username string, reason models.ReportReason, details string) (models.Report, bool, error) {
+	details, err := validateReport(reason, details)
+	if err != nil {
+		return models.Report{}, false, err
+	}
+	username = normalizeUsername(username)
+	if username == "" {
+		return models.Report{}, false, repositories.ErrUserNotFound
+	}
+	target, err := s.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return models.Report{}, false, err
+	}
+	if target.ID == reporterID {
+		return models.Report{}, false, ErrCannotTargetSelf
+	}
+	return s.repo.CreateReport(ctx, models.CreateReportInput{
+		ReporterID:   reporterID,
+		TargetUserID: target.ID,
+		Reason:       reason,
+		Details:      details,
+	})
+}
+
+// DismissModerationItem only closes user reports.
+func (s *Service) DismissModerationItem(ctx context.Context, moderatorID, itemID, note string) (models.ModerationAction, error) {
+	note = strings.TrimSpace(note)
+	if len([]rune(note)) > 1000 {
+		return models.ModerationAction{}, ErrInvalidDecisionNote
+	}
+	item, err := s.repo.GetModerationQueueItem(ctx, itemID)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	if item.PrayerRequestID != nil || item.CommentID != nil {
+		return models.ModerationAction{}, ErrModerationTargetMismatch
+	}
+	ok, err := s.canModerate(ctx, moderatorID, item.GroupID)
+	if err != nil {
+		return models.ModerationAction{}, err
+	}
+	if !ok {
+		return models.ModerationAction{}, ErrPermissionDenied
+	}
+	return s.repo.DismissModerationItem(ctx, moderatorID, itemID, note)
+}
+
+func validateReport(reason models.ReportReason, details string) (string, error) {
+	switch reason {
+	case models.ReportReasonSpam, models.ReportReasonHarassment, models.ReportReasonSelfHarmRisk,
+		models.ReportReasonPersonalData, models.ReportReasonOther:
+	default:
+		return "", ErrInvalidReportReason
+	}
+	details = strings.TrimSpace(details)
+	if len([]rune(details)) > 1000 {
+		return "", ErrInvalidReportDetails
+	}
+	return details, nil
+}
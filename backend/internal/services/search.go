@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"parish-viva/backend/internal/models"
+)
+
+func (s *Service) SearchPrayerRequests(ctx context.Context, in models.SearchPrayerRequestsInput) ([]models.PrayerRequestSearchHit, int64, error) {
+	in.Query = strings.TrimSpace(in.Query)
+	if n := len([]rune(in.Query)); n < 2 || n > 200 {
+		return nil, 0, ErrInvalidSearchQuery
+	}
+	filter, err := normalizeFeedFilter(in.Filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	in.Filter = filter
+	if in.Limit <= 0 || in.Limit > 100 {
+		in.Limit = 20
+	}
+	if in.Offset < 0 {
+		in.Offset = 0
+	}
+	return s.repo.SearchPrayerRequests(ctx, in)
+}
@@ -4,14 +4,41 @@ import (
 	"context"
 	"errors"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/google/uuid"
 
 	"parish-viva/backend/internal/models"
 	"parish-viva/backend/internal/repositories"
 )
 
+// mutualFriendSample is how many mutual friends a profile names.
+const mutualFriendSample = 3
+
 type Service struct {
-	repo repositories.Repository
+	repo    repositories.Repository
+	opts    Options
+	streams *notificationHub
+}
+
+// Options holds the platform-wide switches the service needs from config.
+type Options struct {
+	// PublicModeration queues PUBLIC requests for platform review.
+	PublicModeration bool
+	// ReportAutoHideThreshold of zero disables auto-hiding.
+	ReportAutoHideThreshold int
+	// Archival with a zero InactiveFor is off.
+	Archival models.ArchivalPolicy
+	// NotificationStreamsPerUser of zero means no cap.
+	NotificationStreamsPerUser int
+	// Email configures notification email delivery.
+	Email EmailOptions
+	// Push configures Web Push delivery.
+	Push PushOptions
+	// NotificationRetention of zero keeps read notifications forever.
+	NotificationRetention time.Duration
 }
 
 var ErrInvalidDisplayName = errors.New("invalid displayName")
@@ -27,14 +54,43 @@ var ErrInvalidJoinPolicy = errors.New("invalid joinPolicy")
 var ErrInvalidUsername = errors.New("invalid username")
 var ErrInvalidPrayerActionType = errors.New("invalid prayer action type")
 var ErrInvalidTradition = errors.New("invalid tradition")
+var ErrInvalidLocale = errors.New("invalid locale")
+var ErrInvalidDigestFrequency = errors.New("invalid emailDigest")
+var ErrInvalidTimezone = errors.New("invalid timezone")
+var ErrInvalidNotificationType = errors.New("invalid notification type")
+var ErrInvalidQuietHours = errors.New("invalid quietHours")
+var ErrInvalidMutedGroupID = errors.New("invalid mutedGroupIds")
+var ErrInvalidPushSubscription = errors.New("invalid push subscription")
+var ErrPushDisabled = errors.New("push notifications are not configured")
+var ErrInvalidProfileVisibility = errors.New("invalid profileVisibility")
+var ErrInvalidStatsVisibility = errors.New("invalid statsVisibility")
+var ErrInvalidFriendRequestPolicy = errors.New("invalid friendRequests")
 var ErrPermissionDenied = errors.New("permission denied")
 var ErrLastAdmin = errors.New("cannot remove the last admin")
 var ErrCannotTargetSelf = errors.New("cannot target self for this action")
 var ErrInvalidGroupRole = errors.New("invalid group role")
 var ErrInvalidBio = errors.New("invalid bio")
-
-func NewService(repo repositories.Repository) *Service {
-	return &Service{repo: repo}
+var ErrInvalidModerationStatus = errors.New("invalid moderation status")
+var ErrInvalidModerationReason = errors.New("invalid moderation reason")
+var ErrDecisionReasonRequired = errors.New("reason required")
+var ErrInvalidDecisionReason = errors.New("invalid reason")
+var ErrInvalidDecisionNote = errors.New("invalid note")
+var ErrInvalidBanExpiry = errors.New("expiresAt must be in the future")
+var ErrInvalidReportReason = errors.New("invalid report reason")
+var ErrInvalidReportDetails = errors.New("invalid report details")
+var ErrModerationTargetMismatch = errors.New("action does not apply to this moderation item")
+var ErrInvalidUpdateBody = errors.New("invalid update body")
+var ErrInvalidTestimony = errors.New("invalid testimony")
+var ErrInvalidCommentBody = errors.New("invalid comment body")
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+var ErrInvalidDateRange = errors.New("createdAfter must be before createdBefore")
+var ErrInvalidFeedStatus = errors.New("invalid status")
+var ErrAnsweredWithStatus = errors.New("answered cannot be combined with status")
+var ErrTooManyNotificationStreams = errors.New("too many notification streams")
+var ErrCommentsDisabled = errors.New("comments are disabled on this request")
+
+func NewService(repo repositories.Repository, opts Options) *Service {
+	return &Service{repo: repo, opts: opts, streams: newNotificationHub(opts.NotificationStreamsPerUser)}
 }
 
 func (s *Service) GetProfile(ctx context.Context, userID string) (models.User, error) {
@@ -83,6 +139,15 @@ func (s *Service) GetPublicProfile(ctx context.Context, viewerID, username strin
 	if err != nil {
 		return models.PublicProfile{}, err
 	}
+	if owner.ID != viewerID {
+		blocked, err := s.repo.IsBlocked(ctx, viewerID, owner.ID)
+		if err != nil {
+			return models.PublicProfile{}, err
+		}
+		if blocked {
+			return models.PublicProfile{}, repositories.ErrUserNotFound
+		}
+	}
 	state, incomingID, err := s.repo.GetFriendshipState(ctx, viewerID, owner.ID)
 	if err != nil {
 		return models.PublicProfile{}, err
@@ -92,7 +157,43 @@ func (s *Service) GetPublicProfile(ctx context.Context, viewerID, username strin
 		FriendshipStatus:    state,
 		IncomingFriendReqID: incomingID,
 	}
-	if state == models.PublicFriendshipSelf || state == models.PublicFriendshipFriend {
+	if state == models.PublicFriendshipSelf {
+		stats, err := s.repo.GetUserStats(ctx, owner.ID)
+		if err != nil {
+			return models.PublicProfile{}, err
+		}
+		profile.Stats = &stats
+		return profile, nil
+	}
+
+	// Everything past here is what others may see, per the owner's settings.
+	profile.User.Email = ""
+	privacy, err := s.repo.GetPrivacySettings(ctx, owner.ID)
+	if err != nil {
+		return models.PublicProfile{}, err
+	}
+	if state == models.PublicFriendshipNone {
+		hasMutualFriend := false
+		if privacy.FriendRequests == models.FriendRequestsFriendsOfFriends {
+			if hasMutualFriend, err = s.repo.HasMutualFriend(ctx, viewerID, owner.ID); err != nil {
+				return models.PublicProfile{}, err
+			}
+		}
+		profile.CanSendFriendRequest = acceptsFriendRequest(privacy.FriendRequests, hasMutualFriend)
+	}
+	isFriend := state == models.PublicFriendshipFriend
+	if !audienceIncludes(privacy.ProfileVisibility, isFriend) {
+		profile.Restricted = true
+		profile.User.AvatarURL = nil
+		profile.User.Bio = nil
+		return profile, nil
+	}
+	connection, err := s.repo.GetProfileConnection(ctx, viewerID, owner.ID, mutualFriendSample)
+	if err != nil {
+		return models.PublicProfile{}, err
+	}
+	profile.ProfileConnection = &connection
+	if audienceIncludes(privacy.StatsVisibility, isFriend) {
 		stats, err := s.repo.GetUserStats(ctx, owner.ID)
 		if err != nil {
 			return models.PublicProfile{}, err
@@ -139,6 +240,12 @@ func (s *Service) CreatePrayerRequest(ctx context.Context, in models.CreatePraye
 	if in.Visibility == models.VisibilityPrivate && len(in.GroupIDs) > 0 {
 		return models.PrayerRequest{}, ErrPrivateCannotHaveGroups
 	}
+	reviewGroupIDs, reviewPublic, err := s.reviewScopes(ctx, in.AuthorID, in.Visibility, in.GroupIDs)
+	if err != nil {
+		return models.PrayerRequest{}, err
+	}
+	in.ReviewGroupIDs = reviewGroupIDs
+	in.ReviewPublic = reviewPublic
 	return s.repo.CreatePrayerRequest(ctx, in)
 }
 
@@ -163,6 +270,12 @@ func (s *Service) UpdatePrayerRequest(ctx context.Context, in models.UpdatePraye
 	if in.Visibility == models.VisibilityPrivate && len(in.GroupIDs) > 0 {
 		return models.PrayerRequest{}, ErrPrivateCannotHaveGroups
 	}
+	reviewGroupIDs, reviewPublic, err := s.reviewScopes(ctx, in.EditorID, in.Visibility, in.GroupIDs)
+	if err != nil {
+		return models.PrayerRequest{}, err
+	}
+	in.ReviewGroupIDs = reviewGroupIDs
+	in.ReviewPublic = reviewPublic
 	return s.repo.UpdatePrayerRequest(ctx, in)
 }
 
@@ -174,18 +287,44 @@ func (s *Service) GetPrayerRequestByID(ctx context.Context, userID, requestID st
 	return s.repo.GetPrayerRequestByID(ctx, userID, requestID)
 }
 
-func (s *Service) ListPublicPrayerRequests(ctx context.Context, viewerUserID string, limit, offset int) ([]models.PrayerRequest, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 20
+func (s *Service) ListPublicPrayerRequests(ctx context.Context, viewerUserID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, *models.FeedCursor, error) {
+	return pageFeed(filter, page, func(filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error) {
+		return s.repo.ListPublicPrayerRequests(ctx, viewerUserID, filter, page)
+	})
+}
+
+// pageFeed fetches one extra item to find the next cursor.
+func pageFeed(filter models.FeedFilter, page models.FeedPage, list func(models.FeedFilter, models.FeedPage) ([]models.PrayerRequest, error)) ([]models.PrayerRequest, *models.FeedCursor, error) {
+	filter, err := normalizeFeedFilter(filter)
+	if err != nil {
+		return nil, nil, err
 	}
-	if offset < 0 {
-		offset = 0
+	if page.Limit <= 0 || page.Limit > 100 {
+		page.Limit = 20
 	}
-	return s.repo.ListPublicPrayerRequests(ctx, viewerUserID, limit, offset)
+	if page.Offset < 0 || page.After != nil {
+		page.Offset = 0
+	}
+	limit := page.Limit
+	page.Limit++
+	items, err := list(filter, page)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(items) <= limit {
+		return items, nil, nil
+	}
+	items = items[:limit]
+	last := items[limit-1]
+	return items, &models.FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID}, nil
 }
 
-func (s *Service) CountPublicPrayerRequests(ctx context.Context, viewerUserID string) (int64, error) {
-	return s.repo.CountPublicPrayerRequests(ctx, viewerUserID)
+func (s *Service) CountPublicPrayerRequests(ctx context.Context, viewerUserID string, filter models.FeedFilter) (int64, error) {
+	filter, err := normalizeFeedFilter(filter)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.CountPublicPrayerRequests(ctx, viewerUserID, filter)
 }
 
 func (s *Service) RecordPrayerAction(ctx context.Context, userID, requestID string, actionType models.PrayerActionType, windowHours int) error {
@@ -209,6 +348,13 @@ func (s *Service) SetUserTradition(ctx context.Context, userID string, tradition
 	return s.repo.SetUserTradition(ctx, userID, tradition)
 }
 
+func (s *Service) SetUserLocale(ctx context.Context, userID string, locale models.Locale) (models.User, error) {
+	if locale != models.LocalePortuguese && locale != models.LocaleEnglish {
+		return models.User{}, ErrInvalidLocale
+	}
+	return s.repo.SetUserLocale(ctx, userID, locale)
+}
+
 func (s *Service) DefaultPrayerActionFor(ctx context.Context, userID string) models.PrayerActionType {
 	user, err := s.repo.GetUserByID(ctx, userID)
 	if err != nil {
@@ -220,46 +366,46 @@ func (s *Service) DefaultPrayerActionFor(ctx context.Context, userID string) mod
 	return models.PrayerActionHailMary
 }
 
-func (s *Service) ListHomePrayerRequests(ctx context.Context, userID string, limit, offset int) ([]models.PrayerRequest, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 20
-	}
-	if offset < 0 {
-		offset = 0
+func (s *Service) ListHomePrayerRequests(ctx context.Context, userID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, *models.FeedCursor, error) {
+	return pageFeed(filter, page, func(filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error) {
+		return s.repo.ListHomePrayerRequests(ctx, userID, filter, page)
+	})
+}
+
+func (s *Service) CountHomePrayerRequests(ctx context.Context, userID string, filter models.FeedFilter) (int64, error) {
+	filter, err := normalizeFeedFilter(filter)
+	if err != nil {
+		return 0, err
 	}
-	return s.repo.ListHomePrayerRequests(ctx, userID, limit, offset)
+	return s.repo.CountHomePrayerRequests(ctx, userID, filter)
 }
 
-func (s *Service) CountHomePrayerRequests(ctx context.Context, userID string) (int64, error) {
-	return s.repo.CountHomePrayerRequests(ctx, userID)
+func (s *Service) ListGroupsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, *models.FeedCursor, error) {
+	return pageFeed(filter, page, func(filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error) {
+		return s.repo.ListGroupsPrayerRequests(ctx, userID, filter, page)
+	})
 }
 
-func (s *Service) ListGroupsPrayerRequests(ctx context.Context, userID string, limit, offset int) ([]models.PrayerRequest, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 20
-	}
-	if offset < 0 {
-		offset = 0
+func (s *Service) CountGroupsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter) (int64, error) {
+	filter, err := normalizeFeedFilter(filter)
+	if err != nil {
+		return 0, err
 	}
-	return s.repo.ListGroupsPrayerRequests(ctx, userID, limit, offset)
+	return s.repo.CountGroupsPrayerRequests(ctx, userID, filter)
 }
 
-func (s *Service) CountGroupsPrayerRequests(ctx context.Context, userID string) (int64, error) {
-	return s.repo.CountGroupsPrayerRequests(ctx, userID)
+func (s *Service) ListFriendsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, *models.FeedCursor, error) {
+	return pageFeed(filter, page, func(filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error) {
+		return s.repo.ListFriendsPrayerRequests(ctx, userID, filter, page)
+	})
 }
 
-func (s *Service) ListFriendsPrayerRequests(ctx context.Context, userID string, limit, offset int) ([]models.PrayerRequest, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 20
-	}
-	if offset < 0 {
-		offset = 0
+func (s *Service) CountFriendsPrayerRequests(ctx context.Context, userID string, filter models.FeedFilter) (int64, error) {
+	filter, err := normalizeFeedFilter(filter)
+	if err != nil {
+		return 0, err
 	}
-	return s.repo.ListFriendsPrayerRequests(ctx, userID, limit, offset)
-}
-
-func (s *Service) CountFriendsPrayerRequests(ctx context.Context, userID string) (int64, error) {
-	return s.repo.CountFriendsPrayerRequests(ctx, userID)
+	return s.repo.CountFriendsPrayerRequests(ctx, userID, filter)
 }
 
 func (s *Service) ListUserGroups(ctx context.Context, userID string) ([]models.Group, error) {
@@ -320,7 +466,7 @@ func (s *Service) ListGroupMembers(ctx context.Context, viewerUserID, groupID st
 	} else if !isMember {
 		return nil, 0, ErrPermissionDenied
 	}
-	return s.repo.ListGroupMembers(ctx, groupID, limit, offset)
+	return s.repo.ListGroupMembers(ctx, viewerUserID, groupID, limit, offset)
 }
 
 func (s *Service) ChangeMemberRole(ctx context.Context, actorUserID, groupID, targetUserID string, newRole models.GroupRole) error {
@@ -443,24 +589,24 @@ func (s *Service) UpdateGroup(ctx context.Context, actorUserID, groupID string,
 	return s.repo.UpdateGroup(ctx, groupID, in)
 }
 
-func (s *Service) ListPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string, limit, offset int) ([]models.PrayerRequest, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 20
-	}
-	if offset < 0 {
-		offset = 0
-	}
+func (s *Service) ListPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string, filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, *models.FeedCursor, error) {
 	if err := s.ensureGroupFeedAccess(ctx, viewerUserID, groupID); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return s.repo.ListPrayerRequestsByGroup(ctx, viewerUserID, groupID, limit, offset)
+	return pageFeed(filter, page, func(filter models.FeedFilter, page models.FeedPage) ([]models.PrayerRequest, error) {
+		return s.repo.ListPrayerRequestsByGroup(ctx, viewerUserID, groupID, filter, page)
+	})
 }
 
-func (s *Service) CountPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string) (int64, error) {
+func (s *Service) CountPrayerRequestsByGroup(ctx context.Context, viewerUserID, groupID string, filter models.FeedFilter) (int64, error) {
 	if err := s.ensureGroupFeedAccess(ctx, viewerUserID, groupID); err != nil {
 		return 0, err
 	}
-	return s.repo.CountPrayerRequestsByGroup(ctx, viewerUserID, groupID)
+	filter, err := normalizeFeedFilter(filter)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.CountPrayerRequestsByGroup(ctx, viewerUserID, groupID, filter)
 }
 
 func (s *Service) ensureGroupFeedAccess(ctx context.Context, viewerUserID, groupID string) error {
@@ -468,6 +614,9 @@ func (s *Service) ensureGroupFeedAccess(ctx context.Context, viewerUserID, group
 	if err != nil {
 		return err
 	}
+	if err = s.ensureNotGroupBanned(ctx, groupID, viewerUserID); err != nil {
+		return err
+	}
 	if details.JoinPolicy == models.JoinPolicyInviteOnly && !details.IsMember {
 		return ErrPermissionDenied
 	}
@@ -494,6 +643,31 @@ func (s *Service) AcceptFriendRequest(ctx context.Context, userID, requestID str
 	return s.repo.AcceptFriendRequest(ctx, userID, requestID)
 }
 
+func (s *Service) ListOutgoingFriendRequests(ctx context.Context, userID string) ([]models.OutgoingFriendRequest, error) {
+	return s.repo.ListOutgoingFriendRequests(ctx, userID)
+}
+
+func (s *Service) RejectFriendRequest(ctx context.Context, userID, requestID string) error {
+	if _, err := uuid.Parse(requestID); err != nil {
+		return repositories.ErrFriendRequestNotFound
+	}
+	return s.repo.RejectFriendRequest(ctx, userID, requestID)
+}
+
+func (s *Service) CancelFriendRequest(ctx context.Context, userID, requestID string) error {
+	if _, err := uuid.Parse(requestID); err != nil {
+		return repositories.ErrFriendRequestNotFound
+	}
+	return s.repo.CancelFriendRequest(ctx, userID, requestID)
+}
+
+func (s *Service) RemoveFriend(ctx context.Context, userID, friendUserID string) error {
+	if _, err := uuid.Parse(friendUserID); err != nil {
+		return repositories.ErrFriendNotFound
+	}
+	return s.repo.RemoveFriend(ctx, userID, friendUserID)
+}
+
 func (s *Service) SearchUsersForFriendship(ctx context.Context, userID, query string, limit int) ([]models.UserSummary, error) {
 	query = strings.TrimSpace(strings.TrimPrefix(strings.ToLower(query), "@"))
 	if query == "" {
@@ -560,14 +734,19 @@ func isValidTradition(tradition models.Tradition) bool {
 	}
 }
 
-func (s *Service) ListNotifications(ctx context.Context, userID string, limit, offset int) ([]models.NotificationView, error) {
+func (s *Service) ListNotifications(ctx context.Context, userID string, filter models.NotificationFilter, limit, offset int) ([]models.NotificationView, error) {
 	if limit <= 0 || limit > 50 {
 		limit = 20
 	}
 	if offset < 0 {
 		offset = 0
 	}
-	return s.repo.ListNotifications(ctx, userID, limit, offset)
+	for _, t := range filter.Types {
+		if !slices.Contains(models.NotificationTypes, t) {
+			return nil, ErrInvalidNotificationType
+		}
+	}
+	return s.repo.ListNotifications(ctx, userID, filter, limit, offset)
 }
 
 func (s *Service) CountUnreadNotifications(ctx context.Context, userID string) (int64, error) {
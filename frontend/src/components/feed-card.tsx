@@ -18,6 +18,14 @@ export type FeedCardItem = {
   createdAt: string
   prayedCount: number
   prayerTypeCounts?: Record<string, number>
+  status?: string
+  review?: {
+    pendingGroupNames: string[]
+    platformReview: boolean
+    changesRequested: boolean
+    reason?: string
+    note?: string
+  }
 }
 
 type PrayerHit = { requestID: string; actionType: string; fxID: number } | null
@@ -109,6 +117,14 @@ function FeedCardComponent({
               {extraGroupsCount > 0 && <span className="text-primary/70">+{extraGroupsCount}</span>}
             </span>
           )}
+          {item.status === 'PENDING_REVIEW' && (
+            <span
+              className="rounded-full border border-dashed border-primary/60 bg-panel px-2 py-0.5 text-[10px] font-semibold text-primary"
+              title={item.review?.reason || item.review?.pendingGroupNames.join(', ') || undefined}
+            >
+              {item.review?.changesRequested ? 'Ajustes solicitados' : 'Em análise'}
+            </span>
+          )}
           {isOwner && (
             <Link
               to={`/requests/${item.id}`}